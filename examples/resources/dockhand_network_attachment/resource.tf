@@ -0,0 +1,6 @@
+resource "dockhand_network_attachment" "example" {
+  env          = "1"
+  network_id   = dockhand_network.shared.id
+  container_id = dockhand_container.example.id
+  aliases      = ["api"]
+}
@@ -72,13 +72,20 @@ func Login(ctx context.Context, endpoint string, username string, password strin
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
 
+	logCtx := apiLogContext(ctx, password, mfaToken)
+	logAPIRequest(logCtx, http.MethodPost, fullURL, 0, data)
+	started := time.Now()
+
 	res, err := httpClient.Do(req)
 	if err != nil {
+		logAPIResponse(logCtx, http.MethodPost, fullURL, 0, 0, started, err)
 		return "", err
 	}
 	defer res.Body.Close()
 
 	b, _ := io.ReadAll(res.Body)
+	logAPIResponse(logCtx, http.MethodPost, fullURL, 0, res.StatusCode, started, nil)
+	logAPIResponseBody(logCtx, http.MethodPost, fullURL, res.Header, b)
 	if res.StatusCode < 200 || res.StatusCode > 299 {
 		var lr loginResponse
 		if err := json.Unmarshal(b, &lr); err == nil && lr.Error != "" {
@@ -103,3 +110,153 @@ func Login(ctx context.Context, endpoint string, username string, password strin
 
 	return "", fmt.Errorf("dockhand login succeeded but no dockhand_session cookie was returned")
 }
+
+type loginCredentials struct {
+	username string
+	password string
+	provider string
+	// mfa records that login needed a one-time MFA code. Such codes cannot
+	// be replayed, so the session is not renewed.
+	mfa bool
+}
+
+// SetLoginCredentials stores the credentials used to renew the session when
+// Dockhand rejects a request with 401 (for example after the session timeout
+// configured in auth settings has elapsed).
+func (c *Client) SetLoginCredentials(username string, password string, mfaToken string, provider string) {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+
+	if username == "" || password == "" {
+		c.credentials = nil
+		return
+	}
+	c.credentials = &loginCredentials{
+		username: username,
+		password: password,
+		provider: provider,
+		mfa:      mfaToken != "",
+	}
+}
+
+// SetAPIToken configures token-based auth. The token is sent as
+// `Authorization: Bearer <token>` on every request.
+func (c *Client) SetAPIToken(token string) {
+	c.apiToken = strings.TrimSpace(token)
+}
+
+func (c *Client) setAuthHeaders(req *http.Request, cookie string) {
+	if c.apiToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiToken)
+	}
+	if cookie != "" {
+		req.Header.Set("Cookie", cookie)
+	}
+}
+
+func (c *Client) currentSessionCookie() string {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	return c.sessionCookie
+}
+
+func (c *Client) canReauthenticate() bool {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	return c.credentials != nil
+}
+
+// reauthenticate logs in again unless another request already replaced the
+// stale cookie. Holding sessionMu for the duration of the login means
+// concurrent callers wait for, and then reuse, a single new session.
+func (c *Client) reauthenticate(ctx context.Context, staleCookie string) error {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+
+	if c.credentials == nil {
+		return fmt.Errorf("dockhand session expired and no login credentials are configured")
+	}
+	if c.sessionCookie != staleCookie {
+		return nil
+	}
+	if c.credentials.mfa {
+		return fmt.Errorf("dockhand session expired and cannot be renewed because login used a one-time `mfa_token`; accounts with MFA should use `token` for long runs")
+	}
+
+	cookie, err := Login(ctx, c.baseURL.String(), c.credentials.username, c.credentials.password, "", c.credentials.provider, c.insecure)
+	if err != nil {
+		return fmt.Errorf("dockhand session expired and re-login failed: %w", err)
+	}
+	c.sessionCookie = cookie
+	return nil
+}
+
+// sendWithReauth sends the request built by newReq and, if Dockhand answers
+// 401, renews the session and sends a freshly built request once more. When
+// ctx has no deadline the request, including reading the streamed body, is
+// bounded by defaultStreamTimeout.
+func (c *Client) sendWithReauth(ctx context.Context, newReq func(cookie string) (*http.Request, error)) (*http.Response, error) {
+	ctx, cancel := streamContext(ctx)
+	res, err := c.sendWithReauthContext(ctx, newReq)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	res.Body = &releaseOnClose{ReadCloser: res.Body, release: cancel}
+	return res, nil
+}
+
+func (c *Client) sendWithReauthContext(ctx context.Context, newReq func(cookie string) (*http.Request, error)) (*http.Response, error) {
+	cookie := c.currentSessionCookie()
+	res, err := c.sendLogged(ctx, 0, newReq, cookie)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusUnauthorized || !c.canReauthenticate() {
+		return res, nil
+	}
+	res.Body.Close()
+
+	logAPIRetry(c.logContext(ctx), res.Request.Method, res.Request.URL.String(), 0, "session expired")
+	if err := c.reauthenticate(ctx, cookie); err != nil {
+		return nil, err
+	}
+	return c.sendLogged(ctx, 1, newReq, c.currentSessionCookie())
+}
+
+// sendLogged builds and sends a single streaming request, logging it like
+// doJSONWithStatus does. Response bodies are logged by the caller.
+func (c *Client) sendLogged(ctx context.Context, attempt int, newReq func(cookie string) (*http.Request, error), cookie string) (*http.Response, error) {
+	req, err := newReq(cookie)
+	if err != nil {
+		return nil, err
+	}
+	// newReq captured the caller's context; apply the bounded one.
+	req = req.WithContext(ctx)
+
+	var body []byte
+	if req.GetBody != nil {
+		if rc, err := req.GetBody(); err == nil {
+			body, _ = io.ReadAll(rc)
+			rc.Close()
+		}
+	}
+
+	logCtx := c.logContext(ctx)
+	logAPIRequest(logCtx, req.Method, req.URL.String(), attempt, body)
+	release, err := c.limiter.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	started := time.Now()
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		release()
+		logAPIResponse(logCtx, req.Method, req.URL.String(), attempt, 0, started, err)
+		return nil, err
+	}
+	logAPIResponse(logCtx, req.Method, req.URL.String(), attempt, res.StatusCode, started, nil)
+	// The slot is held until the caller has consumed the stream.
+	res.Body = &releaseOnClose{ReadCloser: res.Body, release: release}
+	return res, nil
+}
@@ -8,19 +8,69 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 type Client struct {
-	baseURL       *url.URL
-	httpClient    *http.Client
+	baseURL    *url.URL
+	httpClient *http.Client
+	defaultEnv string
+	insecure   bool
+
+	// sessionMu guards sessionCookie, which is replaced when an expired
+	// session is renewed with the stored login credentials.
+	sessionMu     sync.Mutex
 	sessionCookie string
-	defaultEnv    string
+	credentials   *loginCredentials
+
+	// apiToken, when set, is sent as a bearer token on every request instead
+	// of relying on a login session.
+	apiToken string
+
+	retry retryConfig
+
+	// envIDs caches environment name -> ID lookups so `env` can be given by
+	// name. envMu also serializes the list call that fills it.
+	envMu  sync.Mutex
+	envIDs map[string]string
+
+	// cache holds short-lived list responses when `response_cache_ttl` is
+	// set; nil disables it.
+	cache *responseCache
+	// sharedCache holds reads marked withSharedResponse while cache is
+	// disabled.
+	sharedCache *responseCache
+
+	// limiter applies `max_concurrent_requests` and `requests_per_second`
+	// to every API request; pullSlots limits image pulls per environment.
+	limiter   *requestLimiter
+	pullSlots *keyedSemaphore
+
+	// objectLocks serializes mutating calls on the same stack or container.
+	objectLocks objectLocks
+}
+
+// retryConfig controls how doJSONWithStatus retries transient failures.
+type retryConfig struct {
+	maxRetries  int
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+	retryOnPost bool
+}
+
+func defaultRetryConfig() retryConfig {
+	return retryConfig{
+		maxRetries: 2,
+		minBackoff: 200 * time.Millisecond,
+		maxBackoff: 5 * time.Second,
+	}
 }
 
 type stackPayload struct {
@@ -28,6 +78,10 @@ type stackPayload struct {
 	Compose string `json:"compose"`
 }
 
+type stackComposePayload struct {
+	Content string `json:"content"`
+}
+
 type stackAdoptItemPayload struct {
 	Name        string `json:"name"`
 	ComposePath string `json:"composePath"`
@@ -64,19 +118,57 @@ type containerPortPayload struct {
 }
 
 type containerPayload struct {
-	Name          string                 `json:"name"`
-	Image         string                 `json:"image"`
-	Command       *string                `json:"command,omitempty"`
-	Env           []string               `json:"env,omitempty"`
-	Labels        map[string]string      `json:"labels,omitempty"`
-	Ports         []containerPortPayload `json:"ports,omitempty"`
-	NetworkMode   *string                `json:"networkMode,omitempty"`
-	RestartPolicy *string                `json:"restartPolicy,omitempty"`
-	Privileged    *bool                  `json:"privileged,omitempty"`
-	TTY           *bool                  `json:"tty,omitempty"`
-	Memory        *int64                 `json:"memory,omitempty"`
-	NanoCPUs      *int64                 `json:"nanoCpus,omitempty"`
-	CapAdd        []string               `json:"capAdd,omitempty"`
+	Name          string                       `json:"name"`
+	Image         string                       `json:"image"`
+	Command       *string                      `json:"command,omitempty"`
+	Env           []string                     `json:"env,omitempty"`
+	Labels        map[string]string            `json:"labels,omitempty"`
+	Ports         []containerPortPayload       `json:"ports,omitempty"`
+	NetworkMode   *string                      `json:"networkMode,omitempty"`
+	RestartPolicy *string                      `json:"restartPolicy,omitempty"`
+	Privileged    *bool                        `json:"privileged,omitempty"`
+	TTY           *bool                        `json:"tty,omitempty"`
+	Memory        *int64                       `json:"memory,omitempty"`
+	NanoCPUs      *int64                       `json:"nanoCpus,omitempty"`
+	CapAdd        []string                     `json:"capAdd,omitempty"`
+	Volumes       []containerMountPayload      `json:"volumes,omitempty"`
+	Healthcheck   *containerHealthcheckPayload `json:"healthcheck,omitempty"`
+}
+
+// containerHealthcheckPayload mirrors Docker's HealthConfig; durations are
+// in nanoseconds.
+type containerHealthcheckPayload struct {
+	Test        []string `json:"test"`
+	Interval    int64    `json:"interval,omitempty"`
+	Timeout     int64    `json:"timeout,omitempty"`
+	Retries     int64    `json:"retries,omitempty"`
+	StartPeriod int64    `json:"startPeriod,omitempty"`
+}
+
+// containerMountPayload follows the config set volume shape
+// (source/target/type/readOnly) with Docker mount options added.
+type containerMountPayload struct {
+	Source        string                         `json:"source,omitempty"`
+	Target        string                         `json:"target"`
+	Type          string                         `json:"type"`
+	ReadOnly      bool                           `json:"readOnly"`
+	VolumeOptions *containerVolumeOptionsPayload `json:"volumeOptions,omitempty"`
+	TmpfsOptions  *containerTmpfsOptionsPayload  `json:"tmpfsOptions,omitempty"`
+}
+
+type containerVolumeOptionsPayload struct {
+	NoCopy       bool                          `json:"noCopy,omitempty"`
+	Labels       map[string]string             `json:"labels,omitempty"`
+	DriverConfig *containerVolumeDriverPayload `json:"driverConfig,omitempty"`
+}
+
+type containerVolumeDriverPayload struct {
+	Name    string            `json:"name"`
+	Options map[string]string `json:"options,omitempty"`
+}
+
+type containerTmpfsOptionsPayload struct {
+	SizeBytes int64 `json:"sizeBytes,omitempty"`
 }
 
 type containerCreateResponse struct {
@@ -96,6 +188,116 @@ type containerResponse struct {
 	Command      *string           `json:"command"`
 }
 
+// containerInspectResponse is the subset of Docker's container inspect
+// payload the container resource refreshes from.
+type containerInspectResponse struct {
+	ID              string                          `json:"Id"`
+	Name            string                          `json:"Name"`
+	State           containerInspectState           `json:"State"`
+	RestartCount    int64                           `json:"RestartCount"`
+	Config          containerInspectConfig          `json:"Config"`
+	Mounts          []containerInspectMount         `json:"Mounts"`
+	HostConfig      containerInspectHostConfig      `json:"HostConfig"`
+	NetworkSettings containerInspectNetworkSettings `json:"NetworkSettings"`
+}
+
+type containerInspectState struct {
+	Status     string                  `json:"Status"`
+	ExitCode   int64                   `json:"ExitCode"`
+	StartedAt  string                  `json:"StartedAt"`
+	FinishedAt string                  `json:"FinishedAt"`
+	Health     *containerInspectHealth `json:"Health"`
+}
+
+type containerInspectHealth struct {
+	Status string `json:"Status"`
+}
+
+// summary converts the inspect payload into the list representation. Docker
+// inspect has no human status line, so one is derived from the state.
+func (i *containerInspectResponse) summary() *containerResponse {
+	out := &containerResponse{
+		ID:           i.ID,
+		Name:         strings.TrimPrefix(i.Name, "/"),
+		Image:        i.Config.Image,
+		State:        i.State.Status,
+		Status:       i.State.Status,
+		RestartCount: i.RestartCount,
+		Labels:       i.Config.Labels,
+	}
+	if i.State.Health != nil {
+		out.Health = i.State.Health.Status
+	}
+	switch i.State.Status {
+	case "running":
+		out.Status = "Up since " + i.State.StartedAt
+	case "exited":
+		out.Status = fmt.Sprintf("Exited (%d) at %s", i.State.ExitCode, i.State.FinishedAt)
+	}
+	if len(i.Config.Cmd) > 0 {
+		command := strings.Join(i.Config.Cmd, " ")
+		out.Command = &command
+	}
+	return out
+}
+
+// containerInspectConfig is the create-time configuration. Env and Labels
+// include entries inherited from the image.
+type containerInspectConfig struct {
+	Image  string            `json:"Image"`
+	Cmd    []string          `json:"Cmd"`
+	Env    []string          `json:"Env"`
+	Labels map[string]string `json:"Labels"`
+}
+
+type containerInspectNetworkSettings struct {
+	// Networks is keyed by network name.
+	Networks map[string]containerInspectEndpoint `json:"Networks"`
+}
+
+type containerInspectEndpoint struct {
+	NetworkID  string                      `json:"NetworkID"`
+	Aliases    []string                    `json:"Aliases"`
+	IPAMConfig *containerInspectIPAMConfig `json:"IPAMConfig"`
+}
+
+type containerInspectIPAMConfig struct {
+	IPv4Address string `json:"IPv4Address"`
+	IPv6Address string `json:"IPv6Address"`
+}
+
+type containerInspectMount struct {
+	Type        string `json:"Type"`
+	Name        string `json:"Name"`
+	Source      string `json:"Source"`
+	Destination string `json:"Destination"`
+	Driver      string `json:"Driver"`
+	RW          bool   `json:"RW"`
+}
+
+type containerInspectHostConfig struct {
+	Mounts       []containerInspectMountSpec              `json:"Mounts"`
+	Privileged   bool                                     `json:"Privileged"`
+	Memory       int64                                    `json:"Memory"`
+	NanoCPUs     int64                                    `json:"NanoCpus"`
+	PortBindings map[string][]containerInspectPortBinding `json:"PortBindings"`
+}
+
+// containerInspectPortBinding is keyed by `<port>/<protocol>` in PortBindings.
+type containerInspectPortBinding struct {
+	HostIP   string `json:"HostIp"`
+	HostPort string `json:"HostPort"`
+}
+
+// containerInspectMountSpec is a mount as requested at create time
+// (HostConfig.Mounts). Docker does not always list tmpfs mounts in Mounts.
+type containerInspectMountSpec struct {
+	Type     string `json:"Type"`
+	Source   string `json:"Source"`
+	Target   string `json:"Target"`
+	ReadOnly bool   `json:"ReadOnly"`
+}
+
 type containerLogsResponse struct {
 	Logs string `json:"logs"`
 }
@@ -181,7 +383,10 @@ type imagePushPayload struct {
 }
 
 type networkContainerPayload struct {
-	ContainerID string `json:"containerId"`
+	ContainerID string   `json:"containerId"`
+	Aliases     []string `json:"aliases,omitempty"`
+	IPv4Address string   `json:"ipv4Address,omitempty"`
+	IPv6Address string   `json:"ipv6Address,omitempty"`
 }
 
 type volumeClonePayload struct {
@@ -538,6 +743,21 @@ type networkPayload struct {
 	Internal   bool              `json:"internal"`
 	Attachable bool              `json:"attachable"`
 	Options    map[string]string `json:"options,omitempty"`
+	EnableIPv6 bool              `json:"enableIPv6,omitempty"`
+	IPAM       *networkIPAM      `json:"ipam,omitempty"`
+}
+
+// networkIPAM is used both in create payloads and in inspect responses.
+type networkIPAM struct {
+	Driver string              `json:"driver,omitempty"`
+	Config []networkIPAMConfig `json:"config,omitempty"`
+}
+
+type networkIPAMConfig struct {
+	Subnet             string            `json:"subnet,omitempty"`
+	Gateway            string            `json:"gateway,omitempty"`
+	IPRange            string            `json:"ipRange,omitempty"`
+	AuxiliaryAddresses map[string]string `json:"auxiliaryAddresses,omitempty"`
 }
 
 type networkResponse struct {
@@ -560,6 +780,17 @@ type networkInspectResponse struct {
 	CreatedAt  *string           `json:"createdAt"`
 	Options    map[string]string `json:"options"`
 	Labels     map[string]string `json:"labels"`
+	EnableIPv6 bool              `json:"enableIPv6"`
+	IPAM       *networkIPAM      `json:"ipam"`
+	// Containers is keyed by container ID.
+	Containers map[string]networkInspectContainer `json:"containers"`
+}
+
+type networkInspectContainer struct {
+	Name        string `json:"name"`
+	EndpointID  string `json:"endpointId"`
+	IPv4Address string `json:"ipv4Address"`
+	IPv6Address string `json:"ipv6Address"`
 }
 
 type volumePayload struct {
@@ -584,13 +815,17 @@ type volumeResponse struct {
 type imagePullPayload struct {
 	Image         string `json:"image"`
 	ScanAfterPull bool   `json:"scanAfterPull"`
+	// RegistryID selects the Dockhand registry whose credentials are used.
+	RegistryID *int64 `json:"registryId,omitempty"`
+	Platform   string `json:"platform,omitempty"`
 }
 
 type imageResponse struct {
-	ID      string   `json:"id"`
-	Tags    []string `json:"tags"`
-	Size    int64    `json:"size"`
-	Created int64    `json:"created"`
+	ID          string   `json:"id"`
+	Tags        []string `json:"tags"`
+	RepoDigests []string `json:"repoDigests"`
+	Size        int64    `json:"size"`
+	Created     int64    `json:"created"`
 }
 
 type authSettingsResponse struct {
@@ -786,12 +1021,16 @@ func NewClient(endpoint string, sessionCookie string, defaultEnv string, insecur
 
 	return &Client{
 		baseURL: parsed,
+		// No client-wide timeout: JSON calls are bounded by requestContext and
+		// streaming calls (image pulls, git deploys) by the caller's context.
 		httpClient: &http.Client{
-			Timeout:   30 * time.Second,
 			Transport: transport,
 		},
 		sessionCookie: sessionCookie,
 		defaultEnv:    defaultEnv,
+		insecure:      insecure,
+		retry:         defaultRetryConfig(),
+		sharedCache:   newResponseCache(sharedResponseTTL),
 	}, nil
 }
 
@@ -940,7 +1179,7 @@ func (c *Client) ListGitStacks(ctx context.Context, env string) ([]gitStackRespo
 	}
 
 	var out []gitStackResponse
-	status, err := c.doJSONWithStatus(ctx, http.MethodGet, "/api/git/stacks", query, nil, &out)
+	status, err := c.doJSONWithStatus(withCachedResponse(ctx), http.MethodGet, "/api/git/stacks", query, nil, &out)
 	if err != nil {
 		return nil, status, err
 	}
@@ -1203,7 +1442,11 @@ func (c *Client) GetScannerSettings(ctx context.Context, envID string, settingsO
 }
 
 func (c *Client) SetScannerSettings(ctx context.Context, envID string, scanner string) (int, error) {
-	parsedEnvID, err := strconv.ParseInt(strings.TrimSpace(envID), 10, 64)
+	resolvedID, err := c.lookupEnvID(ctx, envID)
+	if err != nil {
+		return 0, err
+	}
+	parsedEnvID, err := strconv.ParseInt(resolvedID, 10, 64)
 	if err != nil {
 		return 0, fmt.Errorf("invalid environment id %q for scanner settings: %w", envID, err)
 	}
@@ -1261,7 +1504,7 @@ func (c *Client) ListNetworks(ctx context.Context, env string) ([]networkRespons
 	}
 
 	var out []networkResponse
-	status, err := c.doJSONWithStatus(ctx, http.MethodGet, "/api/networks", query, nil, &out)
+	status, err := c.doJSONWithStatus(withCachedResponse(ctx), http.MethodGet, "/api/networks", query, nil, &out)
 	if err != nil {
 		return nil, status, err
 	}
@@ -1305,13 +1548,18 @@ func (c *Client) DeleteNetwork(ctx context.Context, env string, id string) (int,
 }
 
 func (c *Client) ConnectNetwork(ctx context.Context, env string, id string, containerID string) (int, error) {
+	return c.ConnectNetworkEndpoint(ctx, env, id, networkContainerPayload{
+		ContainerID: containerID,
+	})
+}
+
+// ConnectNetworkEndpoint connects a container with endpoint settings such as
+// aliases and static addresses.
+func (c *Client) ConnectNetworkEndpoint(ctx context.Context, env string, id string, payload networkContainerPayload) (int, error) {
 	query := map[string]string{}
 	if resolvedEnv := c.resolveEnv(env); resolvedEnv != "" {
 		query["env"] = resolvedEnv
 	}
-	payload := networkContainerPayload{
-		ContainerID: containerID,
-	}
 	return c.doJSONWithStatus(ctx, http.MethodPost, "/api/networks/"+url.PathEscape(id)+"/connect", query, payload, nil)
 }
 
@@ -1333,7 +1581,7 @@ func (c *Client) ListVolumes(ctx context.Context, env string) ([]volumeResponse,
 	}
 
 	var out []volumeResponse
-	status, err := c.doJSONWithStatus(ctx, http.MethodGet, "/api/volumes", query, nil, &out)
+	status, err := c.doJSONWithStatus(withCachedResponse(ctx), http.MethodGet, "/api/volumes", query, nil, &out)
 	if err != nil {
 		return nil, status, err
 	}
@@ -1396,7 +1644,7 @@ func (c *Client) ListImages(ctx context.Context, env string) ([]imageResponse, i
 	}
 
 	var out []imageResponse
-	status, err := c.doJSONWithStatus(ctx, http.MethodGet, "/api/images", query, nil, &out)
+	status, err := c.doJSONWithStatus(withCachedResponse(ctx), http.MethodGet, "/api/images", query, nil, &out)
 	if err != nil {
 		return nil, status, err
 	}
@@ -1404,20 +1652,42 @@ func (c *Client) ListImages(ctx context.Context, env string) ([]imageResponse, i
 }
 
 func (c *Client) PullImage(ctx context.Context, env string, image string, scanAfterPull bool) (int, error) {
+	return c.PullImageWithOptions(ctx, env, imagePullPayload{
+		Image:         image,
+		ScanAfterPull: scanAfterPull,
+	})
+}
+
+// PullImageWithOptions pulls an image with registry credentials and platform
+// selection.
+func (c *Client) PullImageWithOptions(ctx context.Context, env string, payload imagePullPayload) (int, error) {
 	query := map[string]string{}
 	if resolvedEnv := c.resolveEnv(env); resolvedEnv != "" {
 		query["env"] = resolvedEnv
 	}
-	payload := imagePullPayload{
-		Image:         image,
-		ScanAfterPull: scanAfterPull,
-	}
 
 	data, err := json.Marshal(payload)
 	if err != nil {
 		return 0, err
 	}
 
+	if env := query["env"]; env != "" {
+		envID, err := c.lookupEnvID(ctx, env)
+		if err != nil {
+			return 0, err
+		}
+		query["env"] = envID
+	}
+
+	// The pull changes the image list of the environment.
+	defer c.invalidateReads(query["env"])
+
+	releasePull, err := c.pullSlots.acquire(ctx, query["env"])
+	if err != nil {
+		return 0, err
+	}
+	defer releasePull()
+
 	ref := &url.URL{Path: "/api/images/pull"}
 	if len(query) > 0 {
 		values := url.Values{}
@@ -1430,38 +1700,35 @@ func (c *Client) PullImage(ctx context.Context, env string, image string, scanAf
 	}
 	fullURL := c.baseURL.ResolveReference(ref).String()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fullURL, bytes.NewReader(data))
-	if err != nil {
-		return 0, err
-	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	if c.sessionCookie != "" {
-		req.Header.Set("Cookie", c.sessionCookie)
-	}
-
-	res, err := c.httpClient.Do(req)
+	res, err := c.sendWithReauth(ctx, func(cookie string) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, fullURL, bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Content-Type", "application/json")
+		c.setAuthHeaders(req, cookie)
+		return req, nil
+	})
 	if err != nil {
 		return 0, err
 	}
 	defer res.Body.Close()
 
-	body, err := io.ReadAll(io.LimitReader(res.Body, 10<<20)) // 10 MiB max stream capture
-	if err != nil {
-		return res.StatusCode, err
-	}
-
 	if res.StatusCode < 200 || res.StatusCode > 299 {
-		if len(body) == 0 {
-			return res.StatusCode, fmt.Errorf("dockhand api returned status %d", res.StatusCode)
+		body, err := io.ReadAll(io.LimitReader(res.Body, maxImagePullLineBytes))
+		if err != nil {
+			return res.StatusCode, err
 		}
-		return res.StatusCode, fmt.Errorf("dockhand api returned status %d: %s", res.StatusCode, strings.TrimSpace(string(body)))
+		logAPIResponseBody(c.logContext(ctx), http.MethodPost, fullURL, res.Header, body)
+		return res.StatusCode, newAPIError(http.MethodPost, ref.Path, res.StatusCode, res.Header, body)
 	}
 
-	if msg := imagePullStreamError(body); msg != "" {
-		return res.StatusCode, fmt.Errorf("dockhand image pull reported error: %s", msg)
+	// The pull streams progress until the image is complete; decode it as it
+	// arrives instead of buffering it.
+	if err := consumeImagePullStream(c.logContext(ctx), res.Body, payload.Image); err != nil {
+		return res.StatusCode, err
 	}
-
 	return res.StatusCode, nil
 }
 
@@ -1551,7 +1818,7 @@ func (c *Client) DeleteLicense(ctx context.Context) (int, error) {
 
 func (c *Client) GetSchedules(ctx context.Context) (*schedulesListResponse, int, error) {
 	var out schedulesListResponse
-	status, err := c.doJSONWithStatus(ctx, http.MethodGet, "/api/schedules", nil, nil, &out)
+	status, err := c.doJSONWithStatus(withCachedResponse(ctx), http.MethodGet, "/api/schedules", nil, nil, &out)
 	if err != nil {
 		return nil, status, err
 	}
@@ -1576,6 +1843,12 @@ func (c *Client) GetScheduleExecutions(ctx context.Context, limit int64, offset
 }
 
 func (c *Client) CreateStack(ctx context.Context, env string, payload stackPayload) error {
+	unlock, err := c.lockObject(ctx, lockKindStack, env, payload.Name)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	query := map[string]string{}
 	if resolvedEnv := c.resolveEnv(env); resolvedEnv != "" {
 		query["env"] = resolvedEnv
@@ -1586,6 +1859,23 @@ func (c *Client) CreateStack(ctx context.Context, env string, payload stackPaylo
 	return nil
 }
 
+func (c *Client) UpdateStackCompose(ctx context.Context, env string, name string, compose string) (int, error) {
+	unlock, err := c.lockObject(ctx, lockKindStack, env, name)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	query := map[string]string{}
+	if resolvedEnv := c.resolveEnv(env); resolvedEnv != "" {
+		query["env"] = resolvedEnv
+	}
+	payload := stackComposePayload{
+		Content: compose,
+	}
+	return c.doJSONWithStatus(ctx, http.MethodPut, "/api/stacks/"+url.PathEscape(name)+"/compose", query, payload, nil)
+}
+
 func (c *Client) ListContainers(ctx context.Context, env string) ([]containerResponse, int, error) {
 	query := map[string]string{}
 	if resolvedEnv := c.resolveEnv(env); resolvedEnv != "" {
@@ -1593,24 +1883,42 @@ func (c *Client) ListContainers(ctx context.Context, env string) ([]containerRes
 	}
 
 	var out []containerResponse
-	status, err := c.doJSONWithStatus(ctx, http.MethodGet, "/api/containers", query, nil, &out)
+	status, err := c.doJSONWithStatus(withCachedResponse(ctx), http.MethodGet, "/api/containers", query, nil, &out)
 	if err != nil {
 		return nil, status, err
 	}
 	return out, status, nil
 }
 
+// ListContainersShared returns the environment's container list, shared
+// through the response cache so it is fetched once per environment until it
+// expires or a change is made there. Each caller decodes its own copy.
+func (c *Client) ListContainersShared(ctx context.Context, env string) ([]containerResponse, error) {
+	containers, _, err := c.ListContainers(withSharedResponse(ctx), env)
+	return containers, err
+}
+
+// invalidateReads drops cached responses after a mutating request. An empty
+// env drops those of every environment.
+func (c *Client) invalidateReads(env string) {
+	for _, cache := range []*responseCache{c.cache, c.sharedCache} {
+		if cache != nil {
+			cache.invalidate(env)
+		}
+	}
+}
+
+// GetContainerByID fetches a single container through the inspect endpoint.
+// A 404 reports the container as not found.
 func (c *Client) GetContainerByID(ctx context.Context, env string, id string) (*containerResponse, bool, error) {
-	containers, _, err := c.ListContainers(ctx, env)
+	inspect, _, err := c.InspectContainer(ctx, env, id)
+	if IsNotFound(err) {
+		return nil, false, nil
+	}
 	if err != nil {
 		return nil, false, err
 	}
-	for i := range containers {
-		if containers[i].ID == id {
-			return &containers[i], true, nil
-		}
-	}
-	return nil, false, nil
+	return inspect.summary(), true, nil
 }
 
 func (c *Client) CreateContainer(ctx context.Context, env string, payload containerPayload) (*containerCreateResponse, int, error) {
@@ -1628,22 +1936,40 @@ func (c *Client) CreateContainer(ctx context.Context, env string, payload contai
 }
 
 func (c *Client) StartContainer(ctx context.Context, env string, id string) (int, error) {
+	unlock, err := c.lockContainer(ctx, env, id)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
 	query := map[string]string{}
 	if resolvedEnv := c.resolveEnv(env); resolvedEnv != "" {
 		query["env"] = resolvedEnv
 	}
-	return c.doJSONWithStatus(ctx, http.MethodPost, "/api/containers/"+url.PathEscape(id)+"/start", query, nil, nil)
+	return c.doJSONWithStatus(withIdempotentRequest(ctx), http.MethodPost, "/api/containers/"+url.PathEscape(id)+"/start", query, nil, nil)
 }
 
 func (c *Client) StopContainer(ctx context.Context, env string, id string) (int, error) {
+	unlock, err := c.lockContainer(ctx, env, id)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
 	query := map[string]string{}
 	if resolvedEnv := c.resolveEnv(env); resolvedEnv != "" {
 		query["env"] = resolvedEnv
 	}
-	return c.doJSONWithStatus(ctx, http.MethodPost, "/api/containers/"+url.PathEscape(id)+"/stop", query, nil, nil)
+	return c.doJSONWithStatus(withIdempotentRequest(ctx), http.MethodPost, "/api/containers/"+url.PathEscape(id)+"/stop", query, nil, nil)
 }
 
 func (c *Client) RestartContainer(ctx context.Context, env string, id string) (int, error) {
+	unlock, err := c.lockContainer(ctx, env, id)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
 	query := map[string]string{}
 	if resolvedEnv := c.resolveEnv(env); resolvedEnv != "" {
 		query["env"] = resolvedEnv
@@ -1652,6 +1978,12 @@ func (c *Client) RestartContainer(ctx context.Context, env string, id string) (i
 }
 
 func (c *Client) RenameContainer(ctx context.Context, env string, id string, name string) (int, error) {
+	unlock, err := c.lockContainer(ctx, env, id)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
 	query := map[string]string{}
 	if resolvedEnv := c.resolveEnv(env); resolvedEnv != "" {
 		query["env"] = resolvedEnv
@@ -1663,6 +1995,12 @@ func (c *Client) RenameContainer(ctx context.Context, env string, id string, nam
 }
 
 func (c *Client) UpdateContainer(ctx context.Context, env string, id string, payload map[string]any) (map[string]any, int, error) {
+	unlock, err := c.lockContainer(ctx, env, id)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer unlock()
+
 	query := map[string]string{}
 	if resolvedEnv := c.resolveEnv(env); resolvedEnv != "" {
 		query["env"] = resolvedEnv
@@ -1676,19 +2014,31 @@ func (c *Client) UpdateContainer(ctx context.Context, env string, id string, pay
 }
 
 func (c *Client) PauseContainer(ctx context.Context, env string, id string) (int, error) {
+	unlock, err := c.lockContainer(ctx, env, id)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
 	query := map[string]string{}
 	if resolvedEnv := c.resolveEnv(env); resolvedEnv != "" {
 		query["env"] = resolvedEnv
 	}
-	return c.doJSONWithStatus(ctx, http.MethodPost, "/api/containers/"+url.PathEscape(id)+"/pause", query, nil, nil)
+	return c.doJSONWithStatus(withIdempotentRequest(ctx), http.MethodPost, "/api/containers/"+url.PathEscape(id)+"/pause", query, nil, nil)
 }
 
 func (c *Client) UnpauseContainer(ctx context.Context, env string, id string) (int, error) {
+	unlock, err := c.lockContainer(ctx, env, id)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
 	query := map[string]string{}
 	if resolvedEnv := c.resolveEnv(env); resolvedEnv != "" {
 		query["env"] = resolvedEnv
 	}
-	return c.doJSONWithStatus(ctx, http.MethodPost, "/api/containers/"+url.PathEscape(id)+"/unpause", query, nil, nil)
+	return c.doJSONWithStatus(withIdempotentRequest(ctx), http.MethodPost, "/api/containers/"+url.PathEscape(id)+"/unpause", query, nil, nil)
 }
 
 func (c *Client) GetContainerLogs(ctx context.Context, env string, id string, tail int64) (*containerLogsResponse, int, error) {
@@ -1742,6 +2092,12 @@ func (c *Client) GetContainerFileContent(ctx context.Context, env string, id str
 }
 
 func (c *Client) CreateContainerFile(ctx context.Context, env string, id string, path string, fileType string) (int, error) {
+	unlock, err := c.lockContainer(ctx, env, id)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
 	query := map[string]string{}
 	if resolvedEnv := c.resolveEnv(env); resolvedEnv != "" {
 		query["env"] = resolvedEnv
@@ -1755,6 +2111,12 @@ func (c *Client) CreateContainerFile(ctx context.Context, env string, id string,
 }
 
 func (c *Client) UpdateContainerFileContent(ctx context.Context, env string, id string, path string, content string) (int, error) {
+	unlock, err := c.lockContainer(ctx, env, id)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
 	query := map[string]string{
 		"path": path,
 	}
@@ -1769,6 +2131,12 @@ func (c *Client) UpdateContainerFileContent(ctx context.Context, env string, id
 }
 
 func (c *Client) DeleteContainerFile(ctx context.Context, env string, id string, path string) (int, error) {
+	unlock, err := c.lockContainer(ctx, env, id)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
 	query := map[string]string{
 		"path": path,
 	}
@@ -1807,6 +2175,22 @@ func (c *Client) GetContainerInspect(ctx context.Context, env string, id string)
 	return out, status, nil
 }
 
+// InspectContainer decodes the inspect payload into the fields the container
+// resource tracks. Use GetContainerInspect for the raw document.
+func (c *Client) InspectContainer(ctx context.Context, env string, id string) (*containerInspectResponse, int, error) {
+	query := map[string]string{}
+	if resolvedEnv := c.resolveEnv(env); resolvedEnv != "" {
+		query["env"] = resolvedEnv
+	}
+
+	var out containerInspectResponse
+	status, err := c.doJSONWithStatus(ctx, http.MethodGet, "/api/containers/"+url.PathEscape(id), query, nil, &out)
+	if err != nil {
+		return nil, status, err
+	}
+	return &out, status, nil
+}
+
 func (c *Client) GetContainerStats(ctx context.Context, env string) ([]containerStatsResponse, int, error) {
 	query := map[string]string{}
 	if resolvedEnv := c.resolveEnv(env); resolvedEnv != "" {
@@ -1849,6 +2233,42 @@ func (c *Client) GetContainerPendingUpdates(ctx context.Context, env string) (*c
 	return &out, status, nil
 }
 
+// PendingImageUpdates returns the environment's pending container updates,
+// as recorded by Dockhand's update checks, in the shape of update check
+// results. The list is shared through the response cache, so it is read once
+// per environment until it expires or a change is made there; reading it
+// never triggers a registry check.
+func (c *Client) PendingImageUpdates(ctx context.Context, env string) ([]containerUpdateCheckResult, error) {
+	out, _, err := c.GetContainerPendingUpdates(withSharedResponse(ctx), env)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]containerUpdateCheckResult, 0, len(out.PendingUpdates))
+	for _, pending := range out.PendingUpdates {
+		results = append(results, pendingUpdateResult(pending))
+	}
+	return results, nil
+}
+
+func pendingUpdateResult(pending map[string]any) containerUpdateCheckResult {
+	result := containerUpdateCheckResult{
+		ContainerID:   firstStringField(pending, "containerId"),
+		ContainerName: firstStringField(pending, "containerName"),
+		ImageName:     firstStringField(pending, "imageName", "image", "currentImage"),
+		HasUpdate:     true,
+	}
+	if hasUpdate, ok := pending["hasUpdate"].(bool); ok {
+		result.HasUpdate = hasUpdate
+	}
+	if digest := firstStringField(pending, "currentDigest"); digest != "" {
+		result.CurrentDigest = &digest
+	}
+	if digest := firstStringField(pending, "latestDigest", "newDigest"); digest != "" {
+		result.LatestDigest = &digest
+	}
+	return result
+}
+
 func (c *Client) ScanImage(ctx context.Context, env string, imageName string) (string, int, error) {
 	query := map[string]string{}
 	if resolvedEnv := c.resolveEnv(env); resolvedEnv != "" {
@@ -1882,16 +2302,19 @@ func (c *Client) GetHawserStatus(ctx context.Context) (*hawserConnectStatus, int
 }
 
 func (c *Client) DeleteContainer(ctx context.Context, env string, id string) (int, error) {
+	unlock, err := c.lockContainer(ctx, env, id)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
 	query := map[string]string{}
 	if resolvedEnv := c.resolveEnv(env); resolvedEnv != "" {
 		query["env"] = resolvedEnv
 	}
 	query["force"] = "true"
 
-	var (
-		status int
-		err    error
-	)
+	var status int
 	for i := range 5 {
 		status, err = c.doJSONWithStatus(ctx, http.MethodDelete, "/api/containers/"+url.PathEscape(id), query, nil, nil)
 		if err == nil || status == http.StatusNotFound {
@@ -1920,7 +2343,7 @@ func (c *Client) ListStacks(ctx context.Context, env string) ([]stackResponse, i
 	}
 
 	var raw json.RawMessage
-	status, err := c.doJSONWithStatus(ctx, http.MethodGet, "/api/stacks", query, nil, &raw)
+	status, err := c.doJSONWithStatus(withCachedResponse(ctx), http.MethodGet, "/api/stacks", query, nil, &raw)
 	if err != nil {
 		return nil, status, err
 	}
@@ -1962,6 +2385,12 @@ func (c *Client) GetStackEnvVars(ctx context.Context, env string, name string) (
 }
 
 func (c *Client) UpdateStackEnvVars(ctx context.Context, env string, name string, variables []stackEnvVariable) (int, error) {
+	unlock, err := c.lockObject(ctx, lockKindStack, env, name)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
 	query := map[string]string{}
 	if resolvedEnv := c.resolveEnv(env); resolvedEnv != "" {
 		query["env"] = resolvedEnv
@@ -1986,6 +2415,12 @@ func (c *Client) GetStackEnvRaw(ctx context.Context, env string, name string) (s
 }
 
 func (c *Client) UpdateStackEnvRaw(ctx context.Context, env string, name string, content string) (int, error) {
+	unlock, err := c.lockObject(ctx, lockKindStack, env, name)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
 	query := map[string]string{}
 	if resolvedEnv := c.resolveEnv(env); resolvedEnv != "" {
 		query["env"] = resolvedEnv
@@ -2002,11 +2437,17 @@ func (c *Client) StartStack(ctx context.Context, env string, name string) error
 }
 
 func (c *Client) StartStackWithStatus(ctx context.Context, env string, name string) (int, error) {
+	unlock, err := c.lockObject(ctx, lockKindStack, env, name)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
 	query := map[string]string{}
 	if resolvedEnv := c.resolveEnv(env); resolvedEnv != "" {
 		query["env"] = resolvedEnv
 	}
-	return c.doJSONWithStatus(ctx, http.MethodPost, "/api/stacks/"+url.PathEscape(name)+"/start", query, nil, nil)
+	return c.doJSONWithStatus(withIdempotentRequest(ctx), http.MethodPost, "/api/stacks/"+url.PathEscape(name)+"/start", query, nil, nil)
 }
 
 func (c *Client) StopStack(ctx context.Context, env string, name string) error {
@@ -2015,14 +2456,26 @@ func (c *Client) StopStack(ctx context.Context, env string, name string) error {
 }
 
 func (c *Client) StopStackWithStatus(ctx context.Context, env string, name string) (int, error) {
+	unlock, err := c.lockObject(ctx, lockKindStack, env, name)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
 	query := map[string]string{}
 	if resolvedEnv := c.resolveEnv(env); resolvedEnv != "" {
 		query["env"] = resolvedEnv
 	}
-	return c.doJSONWithStatus(ctx, http.MethodPost, "/api/stacks/"+url.PathEscape(name)+"/stop", query, nil, nil)
+	return c.doJSONWithStatus(withIdempotentRequest(ctx), http.MethodPost, "/api/stacks/"+url.PathEscape(name)+"/stop", query, nil, nil)
 }
 
 func (c *Client) RestartStackWithStatus(ctx context.Context, env string, name string) (int, error) {
+	unlock, err := c.lockObject(ctx, lockKindStack, env, name)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
 	query := map[string]string{}
 	if resolvedEnv := c.resolveEnv(env); resolvedEnv != "" {
 		query["env"] = resolvedEnv
@@ -2031,6 +2484,12 @@ func (c *Client) RestartStackWithStatus(ctx context.Context, env string, name st
 }
 
 func (c *Client) DownStackWithStatus(ctx context.Context, env string, name string) (int, error) {
+	unlock, err := c.lockObject(ctx, lockKindStack, env, name)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
 	query := map[string]string{}
 	if resolvedEnv := c.resolveEnv(env); resolvedEnv != "" {
 		query["env"] = resolvedEnv
@@ -2039,6 +2498,12 @@ func (c *Client) DownStackWithStatus(ctx context.Context, env string, name strin
 }
 
 func (c *Client) DeleteStack(ctx context.Context, env string, name string) (int, error) {
+	unlock, err := c.lockObject(ctx, lockKindStack, env, name)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
 	query := map[string]string{
 		"force": "true",
 	}
@@ -2075,28 +2540,41 @@ func (c *Client) GetStackSources(ctx context.Context) (map[string]stackSourceRes
 	return out, status, nil
 }
 
-func (c *Client) DeployGitStack(ctx context.Context, id string) (int, string, error) {
+// DeployGitStack runs `/deploy-stream` and decodes its events. A deploy that
+// Dockhand reports as failed inside the stream is returned as a result with
+// Failed() set, not as an error, so callers can keep its log.
+func (c *Client) DeployGitStack(ctx context.Context, id string) (int, *gitDeployResult, error) {
 	endpoint, err := c.baseURL.Parse("/api/git/stacks/" + url.PathEscape(id) + "/deploy-stream")
 	if err != nil {
-		return 0, "", fmt.Errorf("compose deploy URL: %w", err)
+		return 0, nil, fmt.Errorf("compose deploy URL: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), nil)
+	res, err := c.sendWithReauth(ctx, func(cookie string) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		c.setAuthHeaders(req, cookie)
+		return req, nil
+	})
 	if err != nil {
-		return 0, "", err
+		return 0, nil, err
 	}
-	if c.sessionCookie != "" {
-		req.Header.Set("Cookie", c.sessionCookie)
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 1024*1024))
+		logAPIResponseBody(c.logContext(ctx), http.MethodPost, endpoint.String(), res.Header, body)
+		return res.StatusCode, nil, newAPIError(http.MethodPost, endpoint.Path, res.StatusCode, res.Header, body)
 	}
 
-	res, err := c.httpClient.Do(req)
+	// The deploy creates and replaces containers, networks and volumes.
+	defer c.invalidateReads("")
+	result, err := consumeGitDeployStream(c.logContext(ctx), res.Body, id)
 	if err != nil {
-		return 0, "", err
+		return res.StatusCode, nil, err
 	}
-	defer res.Body.Close()
-
-	body, _ := io.ReadAll(io.LimitReader(res.Body, 1024*1024))
-	return res.StatusCode, strings.TrimSpace(string(body)), nil
+	return res.StatusCode, result, nil
 }
 
 func (c *Client) Health(ctx context.Context, env string) (*healthResponse, error) {
@@ -2153,6 +2631,11 @@ func (c *Client) DeleteUser(ctx context.Context, id string) (int, error) {
 }
 
 func (c *Client) doJSONWithStatus(ctx context.Context, method string, path string, query map[string]string, in any, out any) (int, error) {
+	if method != http.MethodGet {
+		// query carries the resolved environment ID by the time this runs.
+		defer func() { c.invalidateReads(requestEnv(query)) }()
+	}
+
 	var payloadBytes []byte
 	if in != nil {
 		data, err := json.Marshal(in)
@@ -2162,6 +2645,24 @@ func (c *Client) doJSONWithStatus(ctx context.Context, method string, path strin
 		payloadBytes = data
 	}
 
+	// Environment names are resolved to IDs here so every env-scoped call
+	// accepts either form.
+	for _, key := range []string{"env", "envId"} {
+		if env := query[key]; env != "" {
+			envID, err := c.lookupEnvID(ctx, env)
+			if err != nil {
+				return 0, err
+			}
+			query[key] = envID
+		}
+	}
+
+	if method == http.MethodGet {
+		if cache := c.responseCacheFor(ctx); cache != nil {
+			return c.cachedGet(ctx, cache, path, query, out)
+		}
+	}
+
 	// Build the URL once; the request itself may be retried.
 	ref := &url.URL{Path: path}
 	if len(query) > 0 {
@@ -2176,31 +2677,51 @@ func (c *Client) doJSONWithStatus(ctx context.Context, method string, path strin
 	fullURL := c.baseURL.ResolveReference(ref).String()
 
 	var lastStatus int
+	var lastHeader http.Header
 	var responseBody []byte
+	reauthenticated := false
+	retryable := c.isRetryableMethod(ctx, method)
+	lastAttempt := c.retry.maxRetries
 
-	for attempt := 0; attempt < 3; attempt++ {
+	for attempt := 0; attempt <= lastAttempt; attempt++ {
 		var body io.Reader
 		if payloadBytes != nil {
 			body = bytes.NewReader(payloadBytes)
 		}
 
-		req, err := http.NewRequestWithContext(ctx, method, fullURL, body)
+		// Waiting for a limiter slot does not count against the per-request
+		// timeout.
+		release, err := c.limiter.acquire(ctx)
 		if err != nil {
 			return 0, err
 		}
+		attemptCtx, cancel := requestContext(ctx)
+		req, err := http.NewRequestWithContext(attemptCtx, method, fullURL, body)
+		if err != nil {
+			release()
+			cancel()
+			return 0, err
+		}
 
 		req.Header.Set("Accept", "application/json")
 		if payloadBytes != nil {
 			req.Header.Set("Content-Type", "application/json")
 		}
-		if c.sessionCookie != "" {
-			req.Header.Set("Cookie", c.sessionCookie)
-		}
+		cookie := c.currentSessionCookie()
+		c.setAuthHeaders(req, cookie)
+
+		logCtx := c.logContext(ctx)
+		logAPIRequest(logCtx, method, fullURL, attempt, payloadBytes)
+		started := time.Now()
 
 		res, err := c.httpClient.Do(req)
 		if err != nil {
-			if shouldRetry(method, 0, err) && attempt < 2 {
-				if sleepErr := sleepBackoff(ctx, attempt); sleepErr != nil {
+			release()
+			cancel()
+			logAPIResponse(logCtx, method, fullURL, attempt, 0, started, err)
+			if retryable && shouldRetry(0, err) && attempt < lastAttempt {
+				logAPIRetry(logCtx, method, fullURL, attempt, "transport error")
+				if sleepErr := c.sleepBackoff(ctx, attempt, 0); sleepErr != nil {
 					return 0, err
 				}
 				continue
@@ -2209,6 +2730,8 @@ func (c *Client) doJSONWithStatus(ctx context.Context, method string, path strin
 		}
 
 		lastStatus = res.StatusCode
+		lastHeader = res.Header
+		retryAfter := parseRetryAfter(res.Header.Get("Retry-After"), time.Now())
 
 		// On errors, keep the body very small to avoid huge allocations in diagnostics.
 		limit := int64(10 << 20) // 10 MiB
@@ -2218,9 +2741,14 @@ func (c *Client) doJSONWithStatus(ctx context.Context, method string, path strin
 
 		responseBody, err = io.ReadAll(io.LimitReader(res.Body, limit))
 		res.Body.Close()
+		release()
+		cancel()
+		logAPIResponse(logCtx, method, fullURL, attempt, lastStatus, started, err)
+		logAPIResponseBody(logCtx, method, fullURL, res.Header, responseBody)
 		if err != nil {
-			if shouldRetry(method, lastStatus, err) && attempt < 2 {
-				if sleepErr := sleepBackoff(ctx, attempt); sleepErr != nil {
+			if retryable && shouldRetry(lastStatus, err) && attempt < lastAttempt {
+				logAPIRetry(logCtx, method, fullURL, attempt, "reading response body failed")
+				if sleepErr := c.sleepBackoff(ctx, attempt, 0); sleepErr != nil {
 					return lastStatus, err
 				}
 				continue
@@ -2228,8 +2756,21 @@ func (c *Client) doJSONWithStatus(ctx context.Context, method string, path strin
 			return lastStatus, err
 		}
 
-		if shouldRetry(method, lastStatus, nil) && attempt < 2 {
-			if sleepErr := sleepBackoff(ctx, attempt); sleepErr != nil {
+		// An expired session is renewed once per request; the retry does not
+		// count against the transient-error attempts.
+		if lastStatus == http.StatusUnauthorized && !reauthenticated && c.canReauthenticate() {
+			reauthenticated = true
+			logAPIRetry(logCtx, method, fullURL, attempt, "session expired")
+			if err := c.reauthenticate(ctx, cookie); err != nil {
+				return lastStatus, err
+			}
+			attempt--
+			continue
+		}
+
+		if retryable && shouldRetry(lastStatus, nil) && attempt < lastAttempt {
+			logAPIRetry(logCtx, method, fullURL, attempt, fmt.Sprintf("status %d", lastStatus))
+			if sleepErr := c.sleepBackoff(ctx, attempt, retryAfter); sleepErr != nil {
 				break
 			}
 			continue
@@ -2239,10 +2780,7 @@ func (c *Client) doJSONWithStatus(ctx context.Context, method string, path strin
 	}
 
 	if lastStatus < 200 || lastStatus > 299 {
-		if len(responseBody) == 0 {
-			return lastStatus, fmt.Errorf("dockhand api returned status %d", lastStatus)
-		}
-		return lastStatus, fmt.Errorf("dockhand api returned status %d: %s", lastStatus, strings.TrimSpace(string(responseBody)))
+		return lastStatus, newAPIError(method, path, lastStatus, lastHeader, responseBody)
 	}
 
 	if out != nil && len(responseBody) > 0 {
@@ -2254,6 +2792,29 @@ func (c *Client) doJSONWithStatus(ctx context.Context, method string, path strin
 	return lastStatus, nil
 }
 
+// defaultRequestTimeout bounds a single JSON API call when the caller's
+// context carries no deadline of its own. Resources with configurable
+// timeouts pass a context with a deadline, which then takes precedence.
+const defaultRequestTimeout = 30 * time.Second
+
+func requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, defaultRequestTimeout)
+}
+
+// defaultStreamTimeout bounds streamed calls such as image pulls and git
+// deploys when the caller's context carries no deadline.
+const defaultStreamTimeout = 20 * time.Minute
+
+func streamContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, defaultStreamTimeout)
+}
+
 func (c *Client) resolveEnv(value string) string {
 	if value != "" {
 		return value
@@ -2261,6 +2822,51 @@ func (c *Client) resolveEnv(value string) string {
 	return c.defaultEnv
 }
 
+// lookupEnvID turns an environment name into its numeric ID. Numeric values
+// pass through unchanged. Names are resolved through ListEnvironments and
+// cached; a cache miss refreshes the list once so environments created
+// earlier in the same run are found.
+func (c *Client) lookupEnvID(ctx context.Context, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || isNumericID(value) {
+		return value, nil
+	}
+
+	c.envMu.Lock()
+	defer c.envMu.Unlock()
+
+	if id, ok := c.envIDs[value]; ok {
+		return id, nil
+	}
+
+	envs, _, err := c.ListEnvironments(ctx)
+	if err != nil {
+		return "", fmt.Errorf("resolve environment %q: %w", value, err)
+	}
+	ids := make(map[string]string, len(envs))
+	for _, env := range envs {
+		ids[env.Name] = strconv.FormatInt(env.ID, 10)
+	}
+	c.envIDs = ids
+
+	if id, ok := ids[value]; ok {
+		return id, nil
+	}
+	return "", fmt.Errorf("dockhand environment %q not found; set `env` to an existing environment name or ID", value)
+}
+
+func isNumericID(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 func parseStacks(raw json.RawMessage) ([]stackResponse, error) {
 	var asArray []map[string]any
 	if err := json.Unmarshal(raw, &asArray); err == nil {
@@ -2282,13 +2888,33 @@ func parseStacks(raw json.RawMessage) ([]stackResponse, error) {
 	return nil, fmt.Errorf("unexpected stack list response shape")
 }
 
-func shouldRetry(method string, status int, err error) bool {
+// SetRetryConfig replaces the retry policy used for API calls.
+func (c *Client) SetRetryConfig(cfg retryConfig) {
+	c.retry = cfg
+}
+
+type idempotentRequestKey struct{}
+
+// withIdempotentRequest marks a POST as safe to repeat (for example starting
+// an already running container), so it may be retried when `retry_on_post`
+// is enabled.
+func withIdempotentRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentRequestKey{}, true)
+}
+
+func (c *Client) isRetryableMethod(ctx context.Context, method string) bool {
 	switch method {
 	case http.MethodGet, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		idempotent, _ := ctx.Value(idempotentRequestKey{}).(bool)
+		return idempotent && c.retry.retryOnPost
 	default:
 		return false
 	}
+}
 
+func shouldRetry(status int, err error) bool {
 	if err != nil {
 		// Don't retry if the context is already cancelled.
 		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
@@ -2310,69 +2936,58 @@ func shouldRetry(method string, status int, err error) bool {
 	}
 }
 
-func sleepBackoff(ctx context.Context, attempt int) error {
-	delay := 200 * time.Millisecond
-	if attempt == 1 {
-		delay = 500 * time.Millisecond
+// backoffDelay returns an exponential delay for the given attempt with
+// "equal jitter": half of the capped delay is fixed, the other half random.
+func (c *Client) backoffDelay(attempt int) time.Duration {
+	delay := c.retry.minBackoff
+	for i := 0; i < attempt && delay < c.retry.maxBackoff; i++ {
+		delay *= 2
 	}
-	t := time.NewTimer(delay)
-	defer t.Stop()
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case <-t.C:
-		return nil
+	if delay > c.retry.maxBackoff {
+		delay = c.retry.maxBackoff
 	}
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + rand.N(delay-half+1)
 }
 
-func imagePullStreamError(body []byte) string {
-	if len(body) == 0 {
-		return ""
+// parseRetryAfter decodes a Retry-After header given in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
 	}
-
-	lines := bytes.Split(body, []byte{'\n'})
-	for _, line := range lines {
-		line = bytes.TrimSpace(line)
-		if len(line) == 0 {
-			continue
-		}
-
-		var obj map[string]any
-		if err := json.Unmarshal(line, &obj); err != nil {
-			continue
-		}
-
-		if status, ok := obj["status"].(string); ok && strings.EqualFold(strings.TrimSpace(status), "error") {
-			if msg := imagePullErrorMessage(obj); msg != "" {
-				return msg
-			}
-			return "unknown pull error"
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
 		}
-
-		if msg := imagePullErrorMessage(obj); msg != "" {
-			return msg
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := at.Sub(now); d > 0 {
+			return d
 		}
 	}
-
-	return ""
+	return 0
 }
 
-func imagePullErrorMessage(obj map[string]any) string {
-	if obj == nil {
-		return ""
+// sleepBackoff waits before the next attempt. A server-provided Retry-After
+// wins over the computed backoff when it is longer.
+func (c *Client) sleepBackoff(ctx context.Context, attempt int, retryAfter time.Duration) error {
+	delay := c.backoffDelay(attempt)
+	if retryAfter > delay {
+		delay = retryAfter
 	}
-
-	if msg, ok := obj["error"].(string); ok && strings.TrimSpace(msg) != "" {
-		return strings.TrimSpace(msg)
-	}
-
-	if detail, ok := obj["errorDetail"].(map[string]any); ok {
-		if msg, ok := detail["message"].(string); ok && strings.TrimSpace(msg) != "" {
-			return strings.TrimSpace(msg)
-		}
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
 	}
-
-	return ""
 }
 
 func mapsToStacks(input []map[string]any) []stackResponse {
@@ -1,6 +1,17 @@
 package provider
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
 
 func TestNewClientAllowsEmptySessionCookie(t *testing.T) {
 	t.Parallel()
@@ -43,6 +54,17 @@ func TestImagePullStreamError(t *testing.T) {
 			body: `not-json` + "\n" + `{"status":"error","error":"pull failed"}`,
 			want: "pull failed",
 		},
+		{
+			name: "sse framing",
+			body: `data: {"status":"Downloading","id":"abc","progressDetail":{"current":1,"total":2}}` + "\n\n" +
+				`data: {"errorDetail":{"message":"unauthorized"}}`,
+			want: "unauthorized",
+		},
+		{
+			name: "oversized line skipped",
+			body: `{"status":"` + strings.Repeat("x", maxImagePullLineBytes) + `"}` + "\n" + `{"error":"after long line"}`,
+			want: "after long line",
+		},
 	}
 
 	for _, tc := range tests {
@@ -50,10 +72,415 @@ func TestImagePullStreamError(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			got := imagePullStreamError([]byte(tc.body))
-			if got != tc.want {
-				t.Fatalf("imagePullStreamError() = %q, want %q", got, tc.want)
+			err := consumeImagePullStream(context.Background(), strings.NewReader(tc.body), "alpine")
+			if tc.want == "" {
+				if err != nil {
+					t.Fatalf("consumeImagePullStream() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.HasSuffix(err.Error(), ": "+tc.want) {
+				t.Fatalf("consumeImagePullStream() = %v, want error %q", err, tc.want)
 			}
 		})
 	}
 }
+
+// failingReader fails the test if the stream is read past the error event.
+type failingReader struct{ t *testing.T }
+
+func (r failingReader) Read([]byte) (int, error) {
+	r.t.Fatalf("pull stream was read after the error event")
+	return 0, nil
+}
+
+func TestImagePullStreamFailsFast(t *testing.T) {
+	t.Parallel()
+
+	body := io.MultiReader(
+		strings.NewReader(`{"status":"Pulling fs layer","id":"abc"}`+"\n"+`{"error":"toomanyrequests"}`+"\n"),
+		failingReader{t: t},
+	)
+	err := consumeImagePullStream(context.Background(), body, "nginx")
+	if err == nil || !strings.Contains(err.Error(), "toomanyrequests") {
+		t.Fatalf("expected pull error, got %v", err)
+	}
+}
+
+func TestSendWithReauthBoundsStreamsWithoutDeadline(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"done"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "", "1", true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	res, err := client.sendWithReauth(context.Background(), func(cookie string) (*http.Request, error) {
+		return http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+"/api/images/pull", nil)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	reqCtx := res.Request.Context()
+	deadline, ok := reqCtx.Deadline()
+	if !ok || time.Until(deadline) > defaultStreamTimeout {
+		t.Fatalf("expected the stream to get the default deadline, got %v (set %t)", deadline, ok)
+	}
+	if _, err := io.ReadAll(res.Body); err != nil {
+		t.Fatalf("unexpected read error: %v", err)
+	}
+	res.Body.Close()
+	if reqCtx.Err() == nil {
+		t.Fatal("expected closing the body to release the stream context")
+	}
+}
+
+func TestClientReauthenticatesOnceOnExpiredSession(t *testing.T) {
+	t.Parallel()
+
+	var logins atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth/login":
+			logins.Add(1)
+			http.SetCookie(w, &http.Cookie{Name: "dockhand_session", Value: "fresh"})
+			_, _ = w.Write([]byte(`{"success":true}`))
+		case "/api/users":
+			if r.Header.Get("Cookie") != "dockhand_session=fresh" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"error":"Unauthorized"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "dockhand_session=expired", "1", true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	client.SetLoginCredentials("admin", "secret", "", "local")
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := client.ListUsers(context.Background()); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatalf("expected request to succeed after re-login, got: %v", err)
+	}
+	if got := logins.Load(); got != 1 {
+		t.Fatalf("expected exactly one re-login, got %d", got)
+	}
+}
+
+func TestClientDoesNotReplayMFAToken(t *testing.T) {
+	t.Parallel()
+
+	var logins atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth/login" {
+			logins.Add(1)
+			http.SetCookie(w, &http.Cookie{Name: "dockhand_session", Value: "fresh"})
+			_, _ = w.Write([]byte(`{"success":true}`))
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"Unauthorized"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "dockhand_session=expired", "1", true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	client.SetLoginCredentials("admin", "secret", "123456", "local")
+
+	_, _, err = client.ListUsers(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "`token`") {
+		t.Fatalf("expected an expired-session error pointing to `token`, got: %v", err)
+	}
+	if got := logins.Load(); got != 0 {
+		t.Fatalf("expected no re-login with a one-time MFA code, got %d", got)
+	}
+}
+
+func TestClientSendsBearerToken(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer ci-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get("Cookie") != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "", "1", true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	client.SetAPIToken("ci-token")
+
+	if _, _, err := client.ListUsers(context.Background()); err != nil {
+		t.Fatalf("expected token-authenticated request to succeed, got: %v", err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	cases := []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "", want: 0},
+		{value: "3", want: 3 * time.Second},
+		{value: "-1", want: 0},
+		{value: now.Add(10 * time.Second).Format(http.TimeFormat), want: 10 * time.Second},
+		{value: now.Add(-10 * time.Second).Format(http.TimeFormat), want: 0},
+		{value: "soon", want: 0},
+	}
+	for _, tc := range cases {
+		if got := parseRetryAfter(tc.value, now); got != tc.want {
+			t.Fatalf("parseRetryAfter(%q) = %s, want %s", tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestBackoffDelayStaysWithinBounds(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{retry: retryConfig{minBackoff: 100 * time.Millisecond, maxBackoff: time.Second}}
+	for attempt := 0; attempt < 8; attempt++ {
+		ceiling := 100 * time.Millisecond << attempt
+		if ceiling > time.Second {
+			ceiling = time.Second
+		}
+		for i := 0; i < 50; i++ {
+			got := client.backoffDelay(attempt)
+			if got < ceiling/2 || got > ceiling {
+				t.Fatalf("attempt %d: delay %s outside [%s, %s]", attempt, got, ceiling/2, ceiling)
+			}
+		}
+	}
+}
+
+func TestClientRetriesIdempotentPostOnlyWhenEnabled(t *testing.T) {
+	t.Parallel()
+
+	for _, retryOnPost := range []bool{false, true} {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		client, err := NewClient(server.URL, "", "1", true)
+		if err != nil {
+			t.Fatalf("unexpected error creating client: %v", err)
+		}
+		client.SetRetryConfig(retryConfig{maxRetries: 2, minBackoff: time.Millisecond, maxBackoff: time.Millisecond, retryOnPost: retryOnPost})
+
+		// A full container ID avoids the inspect that resolves lock keys.
+		status, _ := client.StartContainer(context.Background(), "", strings.Repeat("ab", 32))
+		server.Close()
+
+		wantCalls, wantStatus := int32(1), http.StatusServiceUnavailable
+		if retryOnPost {
+			wantCalls, wantStatus = 2, http.StatusNoContent
+		}
+		if calls.Load() != wantCalls || status != wantStatus {
+			t.Fatalf("retry_on_post=%t: got %d calls and status %d, want %d calls and status %d", retryOnPost, calls.Load(), status, wantCalls, wantStatus)
+		}
+	}
+}
+
+func TestClientReturnsTypedAPIError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-42")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"error":"Stack already exists"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "", "1", true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	err = client.StartStack(context.Background(), "", "web")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusConflict || apiErr.Method != http.MethodPost || apiErr.Path != "/api/stacks/web/start" {
+		t.Fatalf("unexpected error fields: %+v", apiErr)
+	}
+	if apiErr.Message != "Stack already exists" || apiErr.RequestID != "req-42" {
+		t.Fatalf("expected decoded message and request id, got %q / %q", apiErr.Message, apiErr.RequestID)
+	}
+	if !IsConflict(err) || IsNotFound(err) {
+		t.Fatalf("expected IsConflict only, got IsConflict=%t IsNotFound=%t", IsConflict(err), IsNotFound(err))
+	}
+}
+
+func TestClientResolvesEnvironmentNames(t *testing.T) {
+	t.Parallel()
+
+	var listCalls atomic.Int32
+	var gotEnv []string
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/environments" {
+			listCalls.Add(1)
+			_, _ = w.Write([]byte(`[{"id":3,"name":"staging"},{"id":7,"name":"production"}]`))
+			return
+		}
+		mu.Lock()
+		gotEnv = append(gotEnv, r.URL.Query().Get("env"))
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "", "production", true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	ctx := context.Background()
+	containerID := strings.Repeat("ab", 32)
+	for _, env := range []string{"staging", "", "12", "staging"} {
+		if _, err := client.StartContainer(ctx, env, containerID); err != nil {
+			t.Fatalf("unexpected error for env %q: %v", env, err)
+		}
+	}
+	if want := []string{"3", "7", "12", "3"}; strings.Join(gotEnv, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected env query values %v, got %v", want, gotEnv)
+	}
+	if listCalls.Load() != 1 {
+		t.Fatalf("expected environment names to be cached after one list call, got %d calls", listCalls.Load())
+	}
+
+	if _, err := client.StartContainer(ctx, "qa", containerID); err == nil || !strings.Contains(err.Error(), `"qa" not found`) {
+		t.Fatalf("expected unknown environment error, got: %v", err)
+	}
+}
+
+func TestGetContainerByIDUsesInspect(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/containers/abc123":
+			_, _ = w.Write([]byte(`{"Id":"abc123","Name":"/web","RestartCount":2,"State":{"Status":"running","StartedAt":"2026-01-02T03:04:05Z","Health":{"Status":"healthy"}},"Config":{"Image":"nginx:1.27","Cmd":["nginx","-g","daemon off;"]}}`))
+		case "/api/containers/gone":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"No such container"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "", "1", true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	container, found, err := client.GetContainerByID(context.Background(), "", "abc123")
+	if err != nil || !found {
+		t.Fatalf("expected container, got found=%v err=%v", found, err)
+	}
+	if container.Name != "web" || container.State != "running" || container.Health != "healthy" || container.RestartCount != 2 {
+		t.Fatalf("unexpected container summary: %+v", container)
+	}
+	if container.Status != "Up since 2026-01-02T03:04:05Z" {
+		t.Fatalf("unexpected status %q", container.Status)
+	}
+
+	if _, found, err := client.GetContainerByID(context.Background(), "", "gone"); err != nil || found {
+		t.Fatalf("expected missing container, got found=%v err=%v", found, err)
+	}
+}
+
+func TestListContainersSharedUntilMutation(t *testing.T) {
+	t.Parallel()
+
+	var lists atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/containers":
+			lists.Add(1)
+			_, _ = w.Write([]byte(`[{"id":"a","name":"web"},{"id":"b","name":"db"}]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/containers/a/stop":
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "", "1", true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	ctx := context.Background()
+	first, err := client.ListContainersShared(ctx, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first[0].Name = "mutated"
+	second, err := client.ListContainersShared(ctx, "1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lists.Load() != 1 {
+		t.Fatalf("expected one list request, got %d", lists.Load())
+	}
+	if second[0].Name != "web" {
+		t.Fatalf("expected callers to get independent copies, got %q", second[0].Name)
+	}
+
+	if _, err := client.StopContainer(ctx, "", "a"); err != nil {
+		t.Fatalf("unexpected error stopping container: %v", err)
+	}
+	if _, err := client.ListContainersShared(ctx, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lists.Load() != 2 {
+		t.Fatalf("expected mutation to invalidate the shared list, got %d list requests", lists.Load())
+	}
+}
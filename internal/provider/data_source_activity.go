@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -114,7 +115,7 @@ func (d *activityDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	events, _, err := d.client.ListActivity(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading activity", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error reading activity", err)
 		return
 	}
 
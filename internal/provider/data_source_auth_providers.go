@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -93,7 +94,7 @@ func (d *authProvidersDataSource) Read(ctx context.Context, req datasource.ReadR
 
 	apiOut, _, err := d.client.GetAuthProviders(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Dockhand auth providers", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error reading Dockhand auth providers", err)
 		return
 	}
 
@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -103,7 +104,7 @@ func (d *configSetsDataSource) Read(ctx context.Context, req datasource.ReadRequ
 
 	items, _, err := d.client.ListConfigSets(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing Dockhand config sets", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error listing Dockhand config sets", err)
 		return
 	}
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -76,12 +77,12 @@ func (d *containerInspectDataSource) Read(ctx context.Context, req datasource.Re
 
 	inspect, _, err := d.client.GetContainerInspect(ctx, data.Env.ValueString(), data.ContainerID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading container inspect", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error reading container inspect", err)
 		return
 	}
 	raw, err := json.Marshal(inspect)
 	if err != nil {
-		resp.Diagnostics.AddError("Error encoding inspect payload", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error encoding inspect payload", err)
 		return
 	}
 
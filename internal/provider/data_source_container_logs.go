@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -85,7 +86,7 @@ func (d *containerLogsDataSource) Read(ctx context.Context, req datasource.ReadR
 
 	out, _, err := d.client.GetContainerLogs(ctx, data.Env.ValueString(), data.ContainerID.ValueString(), tail)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading container logs", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error reading container logs", err)
 		return
 	}
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -72,7 +73,7 @@ func (d *containerPendingUpdatesDataSource) Read(ctx context.Context, req dataso
 
 	out, _, err := d.client.GetContainerPendingUpdates(ctx, config.Env.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading pending container updates", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error reading pending container updates", err)
 		return
 	}
 
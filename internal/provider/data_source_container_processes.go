@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -99,7 +100,7 @@ func (d *containerProcessesDataSource) Read(ctx context.Context, req datasource.
 
 	out, status, err := d.client.GetContainerTop(ctx, config.Env.ValueString(), containerID)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Dockhand container processes", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error reading Dockhand container processes", err)
 		return
 	}
 	if status < 200 || status > 299 {
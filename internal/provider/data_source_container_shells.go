@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -46,7 +47,7 @@ func (d *containerShellsDataSource) Schema(_ context.Context, _ datasource.Schem
 		MarkdownDescription: "Detects available shells for a container using Dockhand `/api/containers/{id}/shells`.",
 		Attributes: map[string]schema.Attribute{
 			"env": schema.StringAttribute{
-				MarkdownDescription: "Optional environment ID. Sent as `envId` query parameter for this endpoint.",
+				MarkdownDescription: "Optional environment ID or name. Sent as `envId` query parameter for this endpoint.",
 				Optional:            true,
 			},
 			"container_id": schema.StringAttribute{
@@ -103,7 +104,7 @@ func (d *containerShellsDataSource) Read(ctx context.Context, req datasource.Rea
 
 	out, _, err := d.client.GetContainerShells(ctx, data.Env.ValueString(), data.ContainerID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading container shells", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error reading container shells", err)
 		return
 	}
 
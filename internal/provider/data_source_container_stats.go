@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -100,7 +101,7 @@ func (d *containerStatsDataSource) Read(ctx context.Context, req datasource.Read
 
 	out, _, err := d.client.GetContainerStats(ctx, config.Env.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading container stats", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error reading container stats", err)
 		return
 	}
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -51,7 +52,7 @@ func (d *containersDataSource) Schema(_ context.Context, _ datasource.SchemaRequ
 		MarkdownDescription: "Lists containers from Dockhand `/api/containers`.",
 		Attributes: map[string]schema.Attribute{
 			"env": schema.StringAttribute{
-				MarkdownDescription: "Optional environment ID query parameter.",
+				MarkdownDescription: "Optional environment ID or name query parameter.",
 				Optional:            true,
 			},
 			"containers": schema.ListNestedAttribute{
@@ -113,9 +114,9 @@ func (d *containersDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	containers, _, err := d.client.ListContainers(ctx, data.Env.ValueString())
+	containers, err := d.client.ListContainersShared(ctx, data.Env.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing Dockhand containers", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error listing Dockhand containers", err)
 		return
 	}
 
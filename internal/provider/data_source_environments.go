@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -123,7 +124,7 @@ func (d *environmentsDataSource) Read(ctx context.Context, req datasource.ReadRe
 
 	items, _, err := d.client.ListEnvironments(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing Dockhand environments", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error listing Dockhand environments", err)
 		return
 	}
 
@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -122,7 +123,7 @@ func (d *gitRepositoriesDataSource) Read(ctx context.Context, req datasource.Rea
 
 	items, _, err := d.client.ListGitRepositories(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing Dockhand git repositories", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error listing Dockhand git repositories", err)
 		return
 	}
 
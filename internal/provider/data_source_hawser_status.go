@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -78,7 +79,7 @@ func (d *hawserStatusDataSource) Read(ctx context.Context, req datasource.ReadRe
 	var data hawserStatusDataSourceModel
 	status, _, err := d.client.GetHawserStatus(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading hawser status", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error reading hawser status", err)
 		return
 	}
 
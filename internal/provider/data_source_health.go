@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -46,7 +47,7 @@ func (d *healthDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 				Computed:            true,
 			},
 			"env": schema.StringAttribute{
-				MarkdownDescription: "Optional Dockhand environment ID for the health check.",
+				MarkdownDescription: "Optional Dockhand environment ID or name for the health check.",
 				Optional:            true,
 			},
 			"status": schema.StringAttribute{
@@ -96,7 +97,7 @@ func (d *healthDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	health, err := d.client.Health(ctx, config.Env.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error checking Dockhand API health", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error checking Dockhand API health", err)
 		return
 	}
 
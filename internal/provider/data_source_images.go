@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -92,7 +93,7 @@ func (d *imagesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	items, _, err := d.client.ListImages(ctx, data.Env.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing Dockhand images", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error listing Dockhand images", err)
 		return
 	}
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -103,7 +104,7 @@ func (d *networksDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	items, _, err := d.client.ListNetworks(ctx, data.Env.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing Dockhand networks", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error listing Dockhand networks", err)
 		return
 	}
 
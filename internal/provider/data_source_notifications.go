@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -106,7 +107,7 @@ func (d *notificationsDataSource) Read(ctx context.Context, req datasource.ReadR
 
 	items, _, err := d.client.ListNotifications(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing Dockhand notifications", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error listing Dockhand notifications", err)
 		return
 	}
 
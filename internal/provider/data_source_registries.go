@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -102,7 +103,7 @@ func (d *registriesDataSource) Read(ctx context.Context, req datasource.ReadRequ
 
 	items, _, err := d.client.ListRegistries(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing Dockhand registries", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error listing Dockhand registries", err)
 		return
 	}
 
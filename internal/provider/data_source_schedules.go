@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -138,7 +139,7 @@ func (d *schedulesDataSource) Read(ctx context.Context, req datasource.ReadReque
 
 	apiOut, _, err := d.client.GetSchedules(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Dockhand schedules", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error reading Dockhand schedules", err)
 		return
 	}
 
@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -157,7 +158,7 @@ func (d *schedulesExecutionsDataSource) Read(ctx context.Context, req datasource
 
 	apiOut, _, err := d.client.GetScheduleExecutions(ctx, limit, offset)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Dockhand schedule executions", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error reading Dockhand schedule executions", err)
 		return
 	}
 
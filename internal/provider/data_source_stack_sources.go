@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -90,7 +91,7 @@ func (d *stackSourcesDataSource) Read(ctx context.Context, req datasource.ReadRe
 
 	out, _, err := d.client.GetStackSources(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading stack sources", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error reading stack sources", err)
 		return
 	}
 
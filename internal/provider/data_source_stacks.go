@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -90,7 +91,7 @@ func (d *stacksDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	items, _, err := d.client.ListStacks(ctx, data.Env.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading stacks", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error reading stacks", err)
 		return
 	}
 
@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -106,7 +107,7 @@ func (d *usersDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	items, _, err := d.client.ListUsers(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing Dockhand users", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error listing Dockhand users", err)
 		return
 	}
 
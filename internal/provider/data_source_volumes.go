@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -99,7 +100,7 @@ func (d *volumesDataSource) Read(ctx context.Context, req datasource.ReadRequest
 
 	items, _, err := d.client.ListVolumes(ctx, data.Env.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing Dockhand volumes", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error listing Dockhand volumes", err)
 		return
 	}
 
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// APIError is returned by Client methods when Dockhand answers with a non-2xx status.
+type APIError struct {
+	StatusCode int
+	Method     string
+	Path       string
+	RequestID  string
+	// Message is the decoded Dockhand `{"error": "..."}` (or `message`) field,
+	// falling back to the trimmed raw body when it is not JSON.
+	Message string
+	Body    []byte
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "dockhand api returned status %d", e.StatusCode)
+	if e.Method != "" || e.Path != "" {
+		fmt.Fprintf(&b, " for %s %s", e.Method, e.Path)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request id %s)", e.RequestID)
+	}
+	return b.String()
+}
+
+func newAPIError(method string, apiPath string, status int, header http.Header, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: status,
+		Method:     method,
+		Path:       apiPath,
+		Message:    decodeAPIErrorMessage(body),
+		Body:       body,
+	}
+	if header != nil {
+		apiErr.RequestID = header.Get("X-Request-Id")
+	}
+	return apiErr
+}
+
+func decodeAPIErrorMessage(body []byte) string {
+	trimmed := strings.TrimSpace(string(body))
+	if trimmed == "" {
+		return ""
+	}
+
+	var decoded struct {
+		Error   any    `json:"error"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(trimmed), &decoded); err == nil {
+		switch v := decoded.Error.(type) {
+		case string:
+			if v != "" {
+				return v
+			}
+		case map[string]any:
+			if msg, ok := v["message"].(string); ok && msg != "" {
+				return msg
+			}
+		}
+		if decoded.Message != "" {
+			return decoded.Message
+		}
+	}
+
+	const maxLen = 512
+	if len(trimmed) > maxLen {
+		return trimmed[:maxLen] + "..."
+	}
+	return trimmed
+}
+
+// apiErrorStatus returns the HTTP status carried by err, or 0 when err is not an *APIError.
+func apiErrorStatus(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound reports whether err is a Dockhand 404 response.
+func IsNotFound(err error) bool {
+	return apiErrorStatus(err) == http.StatusNotFound
+}
+
+// IsConflict reports whether err is a Dockhand 409 response.
+func IsConflict(err error) bool {
+	return apiErrorStatus(err) == http.StatusConflict
+}
+
+func apiErrorHint(status int) string {
+	switch {
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		return "Dockhand rejected the request. Check the configured values of this resource."
+	case status == http.StatusUnauthorized:
+		return "Check the provider credentials (`username`/`password` or `token`); the session may have expired."
+	case status == http.StatusForbidden:
+		return "The Dockhand user configured for the provider lacks permission for this operation."
+	case status == http.StatusNotFound:
+		return "The object was not found. Check `env` and the identifiers, or remove the resource from state if it was deleted outside Terraform."
+	case status == http.StatusConflict:
+		return "The object conflicts with existing Dockhand state, for example a duplicate name. Choose a different name or import the existing object."
+	case status == http.StatusTooManyRequests:
+		return "Dockhand is rate limiting requests. Raise `max_retries` or `retry_max_backoff` in the provider block."
+	case status >= 500:
+		return "Dockhand reported a server error. Check the Dockhand server logs and retry."
+	default:
+		return ""
+	}
+}
+
+// addAPIError records err as a diagnostic. Dockhand API errors get a
+// readable detail with a remediation hint; attr, when not empty, ties the
+// diagnostic to the attribute that most likely needs attention.
+func addAPIError(diags *diag.Diagnostics, attr path.Path, summary string, err error) {
+	detail := err.Error()
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		var b strings.Builder
+		fmt.Fprintf(&b, "Dockhand returned %d %s", apiErr.StatusCode, http.StatusText(apiErr.StatusCode))
+		if apiErr.Method != "" || apiErr.Path != "" {
+			fmt.Fprintf(&b, " for %s %s", apiErr.Method, apiErr.Path)
+		}
+		if apiErr.Message != "" {
+			fmt.Fprintf(&b, ": %s", apiErr.Message)
+		}
+		if apiErr.RequestID != "" {
+			fmt.Fprintf(&b, "\n\nRequest ID: %s", apiErr.RequestID)
+		}
+		if hint := apiErrorHint(apiErr.StatusCode); hint != "" {
+			fmt.Fprintf(&b, "\n\n%s", hint)
+		}
+		detail = b.String()
+	}
+
+	if attr.Equal(path.Empty()) {
+		diags.AddError(summary, detail)
+		return
+	}
+	diags.AddAttributeError(attr, summary, detail)
+}
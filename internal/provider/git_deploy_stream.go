@@ -0,0 +1,263 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maxGitDeployLogBytes bounds the deploy log kept for state and diagnostics.
+// The tail is kept because compose errors are reported last.
+const maxGitDeployLogBytes = 32 << 10
+
+const (
+	gitDeployEventStep     = "step"
+	gitDeployEventProgress = "progress"
+	gitDeployEventError    = "error"
+	gitDeployEventDone     = "done"
+)
+
+const (
+	gitDeployResultSuccess = "success"
+	gitDeployResultFailed  = "failed"
+)
+
+// gitDeployEvent is one decoded event of the deploy stream.
+type gitDeployEvent struct {
+	Kind     string
+	Step     string
+	Message  string
+	Progress *float64
+	// Success is set by done events that report an explicit outcome.
+	Success *bool
+}
+
+// gitDeployResult is the outcome of a git stack deploy stream.
+type gitDeployResult struct {
+	Result string
+	Error  string
+	Log    string
+}
+
+func (r *gitDeployResult) Failed() bool {
+	return r != nil && r.Result == gitDeployResultFailed
+}
+
+// consumeGitDeployStream decodes the `/deploy-stream` response (SSE or JSON
+// lines) as it arrives. Non-JSON lines are kept in the log verbatim. The
+// deploy counts as failed when any error event is seen, the final event
+// reports `success: false`, or the stream ends without a completion event
+// (an empty body, a dropped connection or a truncated stream).
+func consumeGitDeployStream(ctx context.Context, body io.Reader, stackID string) (*gitDeployResult, error) {
+	lines := newStreamLineReader(body, maxImagePullLineBytes)
+	state := &gitDeployStreamState{ctx: ctx, stackID: stackID}
+
+	var sseEvent string
+	var sseData []string
+	flush := func() {
+		if len(sseData) > 0 {
+			state.handle(parseGitDeployEvent(sseEvent, []byte(strings.Join(sseData, "\n"))))
+		}
+		sseEvent = ""
+		sseData = sseData[:0]
+	}
+
+	for {
+		line, err := lines.next()
+		switch {
+		case len(line) == 0:
+			// Blank lines end an SSE event.
+			flush()
+		case line[0] == ':':
+			// SSE comment / keep-alive.
+		case bytes.HasPrefix(line, []byte("event:")):
+			sseEvent = strings.TrimSpace(string(line[len("event:"):]))
+		case bytes.HasPrefix(line, []byte("data:")):
+			sseData = append(sseData, strings.TrimSpace(string(line[len("data:"):])))
+		default:
+			flush()
+			state.handle(parseGitDeployEvent("", line))
+		}
+		if err == io.EOF {
+			flush()
+			return state.result(), nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading dockhand git stack deploy stream: %w", err)
+		}
+	}
+}
+
+// parseGitDeployEvent turns one event payload into a gitDeployEvent. The SSE
+// event name, when present, takes precedence over fields in the payload.
+func parseGitDeployEvent(name string, data []byte) gitDeployEvent {
+	data = bytes.TrimSpace(data)
+	event := gitDeployEvent{Kind: strings.ToLower(strings.TrimSpace(name))}
+
+	var obj map[string]any
+	if len(data) == 0 || data[0] != '{' || json.Unmarshal(data, &obj) != nil {
+		event.Message = string(data)
+		if event.Kind == "" {
+			event.Kind = gitDeployEventStep
+		}
+		return event
+	}
+
+	status := strings.ToLower(strings.TrimSpace(firstStringField(obj, "type", "status", "event")))
+	event.Step = strings.TrimSpace(firstStringField(obj, "step", "stage", "status"))
+	event.Message = strings.TrimSpace(firstStringField(obj, "message", "output", "log", "data"))
+	if v, ok := obj["progress"].(float64); ok {
+		event.Progress = &v
+	}
+	if v, ok := obj["success"].(bool); ok {
+		event.Success = &v
+	}
+
+	if msg := imagePullErrorMessage(obj); msg != "" {
+		event.Kind = gitDeployEventError
+		event.Message = msg
+		return event
+	}
+	if event.Kind == "" {
+		event.Kind = status
+	}
+	switch event.Kind {
+	case "error", "failed", "failure":
+		event.Kind = gitDeployEventError
+	case "done", "complete", "completed", "success", "finished", "result":
+		event.Kind = gitDeployEventDone
+	case gitDeployEventProgress:
+	default:
+		if event.Success != nil {
+			event.Kind = gitDeployEventDone
+		} else if event.Progress != nil {
+			event.Kind = gitDeployEventProgress
+		} else {
+			event.Kind = gitDeployEventStep
+		}
+	}
+	if event.Kind == gitDeployEventError && event.Message == "" {
+		event.Message = "unknown deploy error"
+	}
+	return event
+}
+
+func firstStringField(obj map[string]any, keys ...string) string {
+	for _, key := range keys {
+		if v, ok := obj[key].(string); ok && strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+type gitDeployStreamState struct {
+	ctx       context.Context
+	stackID   string
+	log       []byte
+	truncated bool
+	errMsg    string
+	success   *bool
+	// completed is set once a done/complete event was seen.
+	completed bool
+}
+
+func (s *gitDeployStreamState) handle(event gitDeployEvent) {
+	fields := map[string]any{
+		"stack_id": s.stackID,
+		"event":    event.Kind,
+	}
+	if event.Step != "" {
+		fields["step"] = event.Step
+	}
+	if event.Progress != nil {
+		fields["progress"] = *event.Progress
+	}
+	tflog.SubsystemDebug(s.ctx, apiLogSubsystem, "Git stack deploy event", fields)
+
+	switch event.Kind {
+	case gitDeployEventError:
+		if s.errMsg == "" {
+			s.errMsg = event.Message
+		}
+		s.appendLog("ERROR: " + event.Message)
+		return
+	case gitDeployEventDone:
+		s.completed = true
+		if event.Success != nil {
+			s.success = event.Success
+			if !*event.Success && s.errMsg == "" && event.Message != "" {
+				s.errMsg = event.Message
+			}
+		}
+	}
+	if event.Message != "" {
+		s.appendLog(event.Message)
+	} else if event.Kind == gitDeployEventStep && event.Step != "" {
+		s.appendLog(event.Step)
+	}
+}
+
+// appendLog adds a line and drops the oldest output once the log grows past
+// twice the limit, so memory stays bounded on long deploys.
+func (s *gitDeployStreamState) appendLog(line string) {
+	s.log = append(s.log, line...)
+	s.log = append(s.log, '\n')
+	if len(s.log) > 2*maxGitDeployLogBytes {
+		s.log = append(s.log[:0], s.log[len(s.log)-maxGitDeployLogBytes:]...)
+		s.truncated = true
+	}
+}
+
+func (s *gitDeployStreamState) result() *gitDeployResult {
+	out := &gitDeployResult{Result: gitDeployResultSuccess}
+	switch {
+	case s.errMsg != "":
+		out.Result = gitDeployResultFailed
+		out.Error = s.errMsg
+	case s.success != nil && !*s.success:
+		out.Result = gitDeployResultFailed
+		out.Error = "deploy reported success=false"
+	case !s.completed:
+		out.Result = gitDeployResultFailed
+		out.Error = "deploy stream ended without a completion event"
+	}
+
+	logBytes := s.log
+	if len(logBytes) > maxGitDeployLogBytes {
+		logBytes = logBytes[len(logBytes)-maxGitDeployLogBytes:]
+		s.truncated = true
+	}
+	if s.truncated {
+		// Drop the partial first line left by the cut.
+		if i := bytes.IndexByte(logBytes, '\n'); i >= 0 {
+			logBytes = logBytes[i+1:]
+		}
+	}
+	log := strings.TrimSpace(string(logBytes))
+	if s.truncated {
+		log = "[earlier output truncated]\n" + log
+	}
+	out.Log = log
+
+	tflog.SubsystemDebug(s.ctx, apiLogSubsystem, "Git stack deploy finished", map[string]any{
+		"stack_id": s.stackID,
+		"result":   out.Result,
+	})
+	return out
+}
+
+// gitDeployFailureDetail formats a failed deploy for a diagnostic, including
+// the tail of the deploy log.
+func gitDeployFailureDetail(result *gitDeployResult) string {
+	detail := result.Error
+	if result.Log != "" {
+		detail += "\n\nDeploy log:\n" + result.Log
+	}
+	return detail
+}
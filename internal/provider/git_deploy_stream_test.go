@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestConsumeGitDeployStream(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		body      string
+		want      string
+		wantError string
+		wantLog   []string
+	}{
+		{
+			name: "sse success",
+			body: "event: step\ndata: {\"status\":\"cloning\",\"message\":\"Cloning repository\"}\n\n" +
+				": keep-alive\n\n" +
+				"data: {\"type\":\"progress\",\"progress\":50,\"message\":\"Pulling images\"}\n\n" +
+				"data: {\"status\":\"complete\",\"success\":true,\"message\":\"Deployed\"}\n\n",
+			want:    gitDeployResultSuccess,
+			wantLog: []string{"Cloning repository", "Pulling images", "Deployed"},
+		},
+		{
+			name: "json lines error event",
+			body: `{"status":"deploying","message":"Starting services"}` + "\n" +
+				`{"status":"error","error":"service web: port is already allocated"}` + "\n",
+			want:      gitDeployResultFailed,
+			wantError: "service web: port is already allocated",
+			wantLog:   []string{"Starting services", "ERROR: service web: port is already allocated"},
+		},
+		{
+			name:      "sse event name error",
+			body:      "event: error\ndata: {\"message\":\"compose up failed\"}\n\n",
+			want:      gitDeployResultFailed,
+			wantError: "compose up failed",
+		},
+		{
+			name:      "done with success false",
+			body:      `{"type":"done","success":false,"message":"exit status 1"}`,
+			want:      gitDeployResultFailed,
+			wantError: "exit status 1",
+		},
+		{
+			name:      "plain text output",
+			body:      "Deploying stack\nStack deployed\n",
+			want:      gitDeployResultFailed,
+			wantError: "deploy stream ended without a completion event",
+			wantLog:   []string{"Deploying stack", "Stack deployed"},
+		},
+		{
+			name:      "empty body",
+			want:      gitDeployResultFailed,
+			wantError: "deploy stream ended without a completion event",
+		},
+		{
+			name:      "stream cut before completion",
+			body:      "data: {\"status\":\"deploying\",\"message\":\"Pulling images\"}\n\ndata: {\"type\":\"progress\",\"progress\":40}\n\n",
+			want:      gitDeployResultFailed,
+			wantError: "deploy stream ended without a completion event",
+			wantLog:   []string{"Pulling images"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := consumeGitDeployStream(context.Background(), strings.NewReader(tt.body), "7")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Result != tt.want {
+				t.Fatalf("expected result %q, got %q (log %q)", tt.want, result.Result, result.Log)
+			}
+			if result.Error != tt.wantError {
+				t.Fatalf("expected error %q, got %q", tt.wantError, result.Error)
+			}
+			for _, line := range tt.wantLog {
+				if !strings.Contains(result.Log, line) {
+					t.Fatalf("expected log to contain %q, got %q", line, result.Log)
+				}
+			}
+		})
+	}
+}
+
+func TestConsumeGitDeployStreamTruncatesLog(t *testing.T) {
+	t.Parallel()
+
+	var body strings.Builder
+	for i := 0; i < 4000; i++ {
+		body.WriteString(`{"status":"deploying","message":"` + strings.Repeat("x", 40) + `"}` + "\n")
+	}
+	body.WriteString(`{"status":"error","error":"last error"}` + "\n")
+
+	result, err := consumeGitDeployStream(context.Background(), strings.NewReader(body.String()), "7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Failed() {
+		t.Fatalf("expected failed result, got %q", result.Result)
+	}
+	if len(result.Log) > maxGitDeployLogBytes+64 {
+		t.Fatalf("expected log capped near %d bytes, got %d", maxGitDeployLogBytes, len(result.Log))
+	}
+	if !strings.HasPrefix(result.Log, "[earlier output truncated]\n") || !strings.HasSuffix(result.Log, "ERROR: last error") {
+		t.Fatalf("expected truncated log ending with the error, got prefix %q suffix %q", result.Log[:40], result.Log[len(result.Log)-40:])
+	}
+}
+
+func TestDeployGitStackReportsStreamFailure(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/git/stacks/7/deploy-stream" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("data: {\"status\":\"deploying\"}\n\ndata: {\"status\":\"error\",\"message\":\"pull access denied\"}\n\n"))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "dockhand_session=test", "1", true)
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	status, result, err := client.DeployGitStack(context.Background(), "7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	if !result.Failed() || result.Error != "pull access denied" {
+		t.Fatalf("expected failed deploy with error, got %+v", result)
+	}
+	if detail := gitDeployFailureDetail(result); !strings.Contains(detail, "Deploy log:\ndeploying") {
+		t.Fatalf("expected failure detail with log, got %q", detail)
+	}
+}
@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maxImagePullLineBytes bounds a single event of the pull stream. Longer
+// lines are skipped, so memory stays constant however long the stream is.
+const maxImagePullLineBytes = 1 << 20
+
+// imagePullProgressInterval throttles repeated progress logs for a layer
+// whose status has not changed.
+const imagePullProgressInterval = 2 * time.Second
+
+// consumeImagePullStream decodes a Docker pull stream (JSON lines, optionally
+// framed as SSE `data:` lines) as it arrives. Layer progress is logged
+// through tflog and the first `error`/`errorDetail` event ends the pull.
+func consumeImagePullStream(ctx context.Context, body io.Reader, image string) error {
+	lines := newStreamLineReader(body, maxImagePullLineBytes)
+	progress := &imagePullProgress{image: image, layers: map[string]imagePullLayer{}}
+	for {
+		line, err := lines.next()
+		if len(line) > 0 {
+			if msg := progress.handle(ctx, line); msg != "" {
+				return fmt.Errorf("dockhand image pull reported error: %s", msg)
+			}
+		}
+		if err == io.EOF {
+			progress.done(ctx)
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading dockhand image pull stream: %w", err)
+		}
+	}
+}
+
+// streamLineReader returns newline-delimited lines from r using a reusable
+// buffer capped at limit bytes.
+type streamLineReader struct {
+	r     *bufio.Reader
+	buf   []byte
+	limit int
+}
+
+func newStreamLineReader(r io.Reader, limit int) *streamLineReader {
+	return &streamLineReader{r: bufio.NewReaderSize(r, 64<<10), limit: limit}
+}
+
+// next returns the next line without its terminator. Lines longer than the
+// limit are consumed and returned empty. The slice is only valid until the
+// following call.
+func (s *streamLineReader) next() ([]byte, error) {
+	s.buf = s.buf[:0]
+	overflow := false
+	for {
+		chunk, err := s.r.ReadSlice('\n')
+		if !overflow && len(s.buf)+len(chunk) <= s.limit {
+			s.buf = append(s.buf, chunk...)
+		} else {
+			overflow = true
+			s.buf = s.buf[:0]
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		return bytes.TrimSpace(s.buf), err
+	}
+}
+
+type imagePullLayer struct {
+	status string
+	logged time.Time
+}
+
+type imagePullProgress struct {
+	image  string
+	layers map[string]imagePullLayer
+	status string
+}
+
+// handle processes one stream line and returns the pull error it reports, if any.
+func (p *imagePullProgress) handle(ctx context.Context, line []byte) string {
+	line = bytes.TrimSpace(bytes.TrimPrefix(line, []byte("data:")))
+	if len(line) == 0 || line[0] != '{' {
+		return ""
+	}
+	var obj map[string]any
+	if err := json.Unmarshal(line, &obj); err != nil {
+		return ""
+	}
+
+	status, _ := obj["status"].(string)
+	status = strings.TrimSpace(status)
+	if msg := imagePullErrorMessage(obj); msg != "" {
+		return msg
+	}
+	if strings.EqualFold(status, "error") {
+		return "unknown pull error"
+	}
+	if status == "" {
+		return ""
+	}
+
+	layerID, _ := obj["id"].(string)
+	fields := map[string]any{
+		"image":  p.image,
+		"status": status,
+	}
+	if layerID == "" {
+		// Stream-level messages such as "Pulling from library/nginx" or the
+		// final "Status: Downloaded newer image".
+		p.status = status
+		tflog.SubsystemDebug(ctx, apiLogSubsystem, "Image pull progress", fields)
+		return ""
+	}
+
+	now := time.Now()
+	prev := p.layers[layerID]
+	if prev.status == status && now.Sub(prev.logged) < imagePullProgressInterval {
+		return ""
+	}
+	p.layers[layerID] = imagePullLayer{status: status, logged: now}
+
+	fields["layer"] = layerID
+	if detail, ok := obj["progressDetail"].(map[string]any); ok {
+		current, _ := detail["current"].(float64)
+		total, _ := detail["total"].(float64)
+		if total > 0 {
+			fields["current_bytes"] = int64(current)
+			fields["total_bytes"] = int64(total)
+			fields["percent"] = int(current * 100 / total)
+		}
+	}
+	tflog.SubsystemDebug(ctx, apiLogSubsystem, "Image pull layer progress", fields)
+	return ""
+}
+
+func (p *imagePullProgress) done(ctx context.Context) {
+	tflog.SubsystemDebug(ctx, apiLogSubsystem, "Image pull finished", map[string]any{
+		"image":  p.image,
+		"layers": len(p.layers),
+		"status": p.status,
+	})
+}
+
+func imagePullErrorMessage(obj map[string]any) string {
+	if obj == nil {
+		return ""
+	}
+
+	if msg, ok := obj["error"].(string); ok && strings.TrimSpace(msg) != "" {
+		return strings.TrimSpace(msg)
+	}
+
+	if detail, ok := obj["errorDetail"].(map[string]any); ok {
+		if msg, ok := detail["message"].(string); ok && strings.TrimSpace(msg) != "" {
+			return strings.TrimSpace(msg)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,275 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// apiLogSubsystem groups HTTP tracing under its own tflog subsystem. It
+// follows TF_LOG_PROVIDER unless TF_LOG_PROVIDER_DOCKHAND_API is set.
+const apiLogSubsystem = "dockhand_api"
+
+// maxLoggedBodyBytes caps request/response bodies written at TRACE level.
+const maxLoggedBodyBytes = 16 << 10
+
+// redactedValue replaces secret values in logged bodies.
+const redactedValue = "***"
+
+// redactedBodyKeys lists JSON keys (compared case-insensitively) whose values
+// never reach the logs.
+var redactedBodyKeys = map[string]struct{}{
+	"password":        {},
+	"newpassword":     {},
+	"currentpassword": {},
+	"mfatoken":        {},
+	"token":           {},
+	"apitoken":        {},
+	"accesstoken":     {},
+	"sshkey":          {},
+	"sshprivatekey":   {},
+	"privatekey":      {},
+	"passphrase":      {},
+	"tlskey":          {},
+	"tlsclientkey":    {},
+	"licensekey":      {},
+	"secret":          {},
+	"webhooksecret":   {},
+	"clientsecret":    {},
+	"cookie":          {},
+	"sessioncookie":   {},
+	"authorization":   {},
+}
+
+// redactedPathKeys masks JSON keys that are only secret on some endpoints.
+// The raw .env file of a stack travels as `content`, a key that elsewhere
+// carries compose manifests and file contents worth logging. Paths are
+// matched against the escaped request path.
+var redactedPathKeys = []struct {
+	path *regexp.Regexp
+	key  string
+}{
+	{regexp.MustCompile(`^/api/stacks/[^/]+/env/raw$`), "content"},
+}
+
+// apiLogContext returns ctx with the Dockhand API subsystem logger. Every
+// literal in secrets is masked from all messages and fields it logs.
+func apiLogContext(ctx context.Context, secrets ...string) context.Context {
+	ctx = tflog.NewSubsystem(ctx, apiLogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_DOCKHAND_API"))
+	var masked []string
+	for _, secret := range secrets {
+		if secret != "" {
+			masked = append(masked, secret)
+		}
+	}
+	if len(masked) > 0 {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, apiLogSubsystem, masked...)
+		ctx = tflog.SubsystemMaskMessageStrings(ctx, apiLogSubsystem, masked...)
+	}
+	return ctx
+}
+
+// logContext prepares a logging context that masks the client's own credentials.
+func (c *Client) logContext(ctx context.Context) context.Context {
+	secrets := []string{c.apiToken, c.currentSessionCookie()}
+	if cookieValue, ok := strings.CutPrefix(c.currentSessionCookie(), "dockhand_session="); ok {
+		secrets = append(secrets, cookieValue)
+	}
+	return apiLogContext(ctx, secrets...)
+}
+
+func logAPIRequest(ctx context.Context, method string, fullURL string, attempt int, body []byte) {
+	tflog.SubsystemDebug(ctx, apiLogSubsystem, "Sending Dockhand API request", map[string]any{
+		"method":  method,
+		"url":     fullURL,
+		"attempt": attempt + 1,
+	})
+	if len(body) > 0 {
+		tflog.SubsystemTrace(ctx, apiLogSubsystem, "Dockhand API request body", map[string]any{
+			"method": method,
+			"url":    fullURL,
+			"body":   redactBody(fullURL, body),
+		})
+	}
+}
+
+func logAPIResponse(ctx context.Context, method string, fullURL string, attempt int, status int, started time.Time, err error) {
+	fields := map[string]any{
+		"method":     method,
+		"url":        fullURL,
+		"attempt":    attempt + 1,
+		"latency_ms": time.Since(started).Milliseconds(),
+	}
+	if status != 0 {
+		fields["status"] = status
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(ctx, apiLogSubsystem, "Dockhand API request failed", fields)
+		return
+	}
+	tflog.SubsystemDebug(ctx, apiLogSubsystem, "Received Dockhand API response", fields)
+}
+
+func logAPIResponseBody(ctx context.Context, method string, fullURL string, header http.Header, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+	fields := map[string]any{
+		"method": method,
+		"url":    fullURL,
+		"body":   redactBody(fullURL, body),
+	}
+	if header != nil {
+		if requestID := header.Get("X-Request-Id"); requestID != "" {
+			fields["request_id"] = requestID
+		}
+	}
+	tflog.SubsystemTrace(ctx, apiLogSubsystem, "Dockhand API response body", fields)
+}
+
+func logAPIRetry(ctx context.Context, method string, fullURL string, attempt int, reason string) {
+	tflog.SubsystemDebug(ctx, apiLogSubsystem, "Retrying Dockhand API request", map[string]any{
+		"method":       method,
+		"url":          fullURL,
+		"next_attempt": attempt + 2,
+		"reason":       reason,
+	})
+}
+
+// redactBody renders the body of a request to or response from fullURL for
+// logging with secret JSON values masked. Non-JSON bodies (for example image
+// pull progress streams) are logged line by line with each JSON line
+// redacted on its own. The whole body is redacted before it is cut to
+// maxLoggedBodyBytes, so a cut never leaves an undecodable, unredacted
+// fragment.
+func redactBody(fullURL string, body []byte) string {
+	redacted := redactFullBody(fullURL, body)
+	if len(redacted) > maxLoggedBodyBytes {
+		redacted = redacted[:maxLoggedBodyBytes]
+	}
+	return redacted
+}
+
+func redactFullBody(fullURL string, body []byte) string {
+	pathKeys := redactedKeysForURL(fullURL)
+	var decoded any
+	if err := json.Unmarshal(body, &decoded); err == nil {
+		if object, ok := decoded.(map[string]any); ok {
+			for _, key := range pathKeys {
+				if value, ok := object[key].(string); ok && value != "" {
+					object[key] = redactedLength(value)
+				}
+			}
+		}
+		out, err := json.Marshal(redactJSONValue(decoded))
+		if err == nil {
+			return string(out)
+		}
+	}
+	// A body that should carry a secret key but does not decode is
+	// logged by size only.
+	if len(pathKeys) > 0 {
+		return redactedLength(string(body))
+	}
+
+	lines := strings.Split(string(body), "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "data:"))
+		if trimmed == "" || (trimmed[0] != '{' && trimmed[0] != '[') {
+			continue
+		}
+		var lineValue any
+		if err := json.Unmarshal([]byte(trimmed), &lineValue); err != nil {
+			continue
+		}
+		if out, err := json.Marshal(redactJSONValue(lineValue)); err == nil {
+			lines[i] = strings.Replace(line, trimmed, string(out), 1)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func redactedKeysForURL(fullURL string) []string {
+	parsed, err := url.Parse(fullURL)
+	if err != nil {
+		return nil
+	}
+	var keys []string
+	for _, rule := range redactedPathKeys {
+		if rule.path.MatchString(parsed.EscapedPath()) {
+			keys = append(keys, rule.key)
+		}
+	}
+	return keys
+}
+
+// redactedLength replaces a secret document with its size.
+func redactedLength(value string) string {
+	return fmt.Sprintf("%s (%d bytes)", redactedValue, len(value))
+}
+
+// redactEnvList masks the values of a Docker `KEY=value` env list, as sent
+// in container create payloads and returned by inspect. Lists of anything
+// else are returned unchanged.
+func redactEnvList(items []any) ([]any, bool) {
+	for _, item := range items {
+		if entry, ok := item.(string); !ok || !strings.Contains(entry, "=") {
+			return items, false
+		}
+	}
+	for i, item := range items {
+		name, _, _ := strings.Cut(item.(string), "=")
+		items[i] = name + "=" + redactedValue
+	}
+	return items, true
+}
+
+func redactJSONValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		secretEntry := false
+		if isSecret, ok := v["isSecret"].(bool); ok && isSecret {
+			secretEntry = true
+		}
+		_, hasValue := v["value"]
+		for key, item := range v {
+			if _, ok := redactedBodyKeys[strings.ToLower(key)]; ok && item != nil && item != "" {
+				v[key] = redactedValue
+				continue
+			}
+			// License payloads carry the key as `key`; env var entries use
+			// `key` for the variable name next to `value`.
+			if key == "key" && !hasValue && item != nil && item != "" {
+				v[key] = redactedValue
+				continue
+			}
+			if secretEntry && key == "value" {
+				v[key] = redactedValue
+				continue
+			}
+			if list, ok := item.([]any); ok && strings.EqualFold(key, "env") {
+				if masked, ok := redactEnvList(list); ok {
+					v[key] = masked
+					continue
+				}
+			}
+			v[key] = redactJSONValue(item)
+		}
+		return v
+	case []any:
+		for i := range v {
+			v[i] = redactJSONValue(v[i])
+		}
+		return v
+	default:
+		return value
+	}
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactBodyMasksSecrets(t *testing.T) {
+	t.Parallel()
+
+	body := `{"username":"admin","password":"hunter2","sshKey":"-----BEGIN KEY-----","nested":{"tlsKey":"tls-secret","webhookSecret":"whsec-1"},"name":"corp","key":"LICENSE-123","variables":[{"key":"DB_USER","value":"app","isSecret":false},{"key":"DB_PASS","value":"pw","isSecret":true}]}`
+	got := redactBody("", []byte(body))
+
+	for _, secret := range []string{"hunter2", "BEGIN KEY", "tls-secret", "whsec-1", "LICENSE-123", `"pw"`} {
+		if strings.Contains(got, secret) {
+			t.Fatalf("expected %q to be redacted, got: %s", secret, got)
+		}
+	}
+	for _, kept := range []string{"admin", "corp", "DB_USER", "DB_PASS", `"app"`} {
+		if !strings.Contains(got, kept) {
+			t.Fatalf("expected %q to be kept, got: %s", kept, got)
+		}
+	}
+
+	// The raw .env file is masked by path, in requests and responses alike.
+	envFile := `{"content":"DB_PASS=hunter2\nAPI_KEY=abc123\n"}`
+	got = redactBody("https://dockhand.example/api/stacks/web/env/raw?env=1", []byte(envFile))
+	if strings.Contains(got, "hunter2") || strings.Contains(got, "abc123") || !strings.Contains(got, "bytes") {
+		t.Fatalf("expected raw env content to be logged by size only, got: %s", got)
+	}
+	got = redactBody("https://dockhand.example/api/stacks/web/env/raw", []byte("DB_PASS=hunter2\n"))
+	if strings.Contains(got, "hunter2") {
+		t.Fatalf("expected non-JSON raw env body to be redacted, got: %s", got)
+	}
+	// `content` stays readable elsewhere.
+	compose := `{"content":"services:\n  web:\n    image: nginx\n"}`
+	if got = redactBody("https://dockhand.example/api/stacks/web/compose", []byte(compose)); !strings.Contains(got, "image: nginx") {
+		t.Fatalf("expected compose content to be kept, got: %s", got)
+	}
+
+	// Container env lists keep the names only.
+	create := `{"name":"api","env":["DB_USER=app","DB_PASS=hunter2"],"Config":{"Env":["TOKEN=abc123"]}}`
+	got = redactBody("https://dockhand.example/api/containers", []byte(create))
+	for _, secret := range []string{"hunter2", "abc123", "=app"} {
+		if strings.Contains(got, secret) {
+			t.Fatalf("expected env value %q to be redacted, got: %s", secret, got)
+		}
+	}
+	if !strings.Contains(got, "DB_PASS=***") || !strings.Contains(got, "TOKEN=***") {
+		t.Fatalf("expected env names to be kept, got: %s", got)
+	}
+	if got = redactBody("", []byte(`{"env":"1"}`)); !strings.Contains(got, `"env":"1"`) {
+		t.Fatalf("expected environment references to be kept, got: %s", got)
+	}
+}
+
+func TestRedactBodyHandlesStreams(t *testing.T) {
+	t.Parallel()
+
+	body := "data: {\"status\":\"cloning\",\"password\":\"s3cret\"}\nplain progress line\n"
+	got := redactBody("", []byte(body))
+	if strings.Contains(got, "s3cret") {
+		t.Fatalf("expected stream line secret to be redacted, got: %s", got)
+	}
+	if !strings.Contains(got, "plain progress line") || !strings.HasPrefix(got, "data: ") {
+		t.Fatalf("expected non-JSON content to be preserved, got: %s", got)
+	}
+}
+
+func TestRedactBodyRedactsBeforeTruncating(t *testing.T) {
+	t.Parallel()
+
+	var body strings.Builder
+	body.WriteString("[")
+	for i := 0; i < 600; i++ {
+		if i > 0 {
+			body.WriteString(",")
+		}
+		body.WriteString(`{"name":"registry","username":"deploy","password":"hunter2-secret"}`)
+	}
+	body.WriteString("]")
+	if body.Len() <= maxLoggedBodyBytes {
+		t.Fatalf("test body must exceed %d bytes, got %d", maxLoggedBodyBytes, body.Len())
+	}
+
+	got := redactBody("", []byte(body.String()))
+	if strings.Contains(got, "hunter2-secret") {
+		t.Fatal("expected secrets in a large body to be redacted")
+	}
+	if len(got) > maxLoggedBodyBytes {
+		t.Fatalf("expected logged body capped at %d bytes, got %d", maxLoggedBodyBytes, len(got))
+	}
+
+	stream := strings.Repeat(`{"status":"pulling","password":"hunter2-secret"}`+"\n", 600)
+	if got := redactBody("", []byte(stream)); strings.Contains(got, "hunter2-secret") {
+		t.Fatal("expected secrets in a large stream to be redacted")
+	}
+}
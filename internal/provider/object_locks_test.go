@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestObjectLocksSerializeSameKey(t *testing.T) {
+	t.Parallel()
+
+	var locks objectLocks
+	release, err := locks.acquire(context.Background(), "1/stack/web")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Another object is not blocked.
+	releaseOther, err := locks.acquire(context.Background(), "1/stack/db")
+	if err != nil {
+		t.Fatalf("expected a different object to proceed, got %v", err)
+	}
+	releaseOther()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := locks.acquire(ctx, "1/stack/web"); err == nil {
+		t.Fatal("expected second lock on the same object to wait")
+	}
+
+	release()
+	release()
+	again, err := locks.acquire(context.Background(), "1/stack/web")
+	if err != nil {
+		t.Fatalf("expected lock after release, got %v", err)
+	}
+	again()
+
+	locks.mu.Lock()
+	defer locks.mu.Unlock()
+	if len(locks.locks) != 0 {
+		t.Fatalf("expected idle locks to be dropped, got %d", len(locks.locks))
+	}
+}
+
+func TestClientSerializesStackMutations(t *testing.T) {
+	t.Parallel()
+
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/environments" {
+			_, _ = w.Write([]byte(`[{"id":1,"name":"local"}]`))
+			return
+		}
+		// Only mutations of stack "web" count towards the peak.
+		if strings.HasPrefix(r.URL.Path, "/api/stacks/web") {
+			n := inFlight.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			inFlight.Add(-1)
+		} else {
+			time.Sleep(20 * time.Millisecond)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "", "1", true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	ctx := context.Background()
+	calls := []func() error{
+		func() error { return client.StopStack(ctx, "1", "web") },
+		// Name and ID of the same environment share a lock.
+		func() error { _, err := client.UpdateStackEnvRaw(ctx, "local", "web", "A=1"); return err },
+		func() error { _, err := client.UpdateStackCompose(ctx, "1", "web", "services: {}"); return err },
+		func() error { return client.StartStack(ctx, "1", "other") },
+	}
+
+	var wg sync.WaitGroup
+	for _, call := range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := call(); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got != 1 {
+		t.Fatalf("expected mutations of one stack to run one at a time, got %d concurrent", got)
+	}
+}
+
+func TestClientLocksContainersByFullID(t *testing.T) {
+	t.Parallel()
+
+	fullID := strings.Repeat("ab", 32)
+	var inFlight, peak, inspects atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/api/containers/web" {
+			inspects.Add(1)
+			_, _ = w.Write([]byte(`{"Id":"` + fullID + `","Name":"/web"}`))
+			return
+		}
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		inFlight.Add(-1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "", "1", true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	ctx := context.Background()
+	calls := []func() error{
+		func() error { _, err := client.StopContainer(ctx, "1", fullID); return err },
+		// The container file resource may address the container by name.
+		func() error {
+			_, err := client.UpdateContainerFileContent(ctx, "1", "web", "/etc/app.conf", "a=1")
+			return err
+		},
+		func() error { _, err := client.RestartContainer(ctx, "1", fullID); return err },
+	}
+
+	var wg sync.WaitGroup
+	for _, call := range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := call(); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got != 1 {
+		t.Fatalf("expected mutations of one container to run one at a time, got %d concurrent", got)
+	}
+	if got := inspects.Load(); got != 1 {
+		t.Fatalf("expected only the name to be resolved through inspect, got %d inspects", got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
@@ -27,14 +28,23 @@ type dockhandProvider struct {
 }
 
 type dockhandProviderModel struct {
-	Endpoint             types.String `tfsdk:"endpoint"`
-	Username             types.String `tfsdk:"username"`
-	Password             types.String `tfsdk:"password"`
-	MFAToken             types.String `tfsdk:"mfa_token"`
-	AuthProvider         types.String `tfsdk:"auth_provider"`
-	DefaultEnv           types.String `tfsdk:"default_env"`
-	Insecure             types.Bool   `tfsdk:"insecure"`
-	AllowUnauthenticated types.Bool   `tfsdk:"allow_unauthenticated"`
+	Endpoint                types.String  `tfsdk:"endpoint"`
+	Username                types.String  `tfsdk:"username"`
+	Password                types.String  `tfsdk:"password"`
+	MFAToken                types.String  `tfsdk:"mfa_token"`
+	Token                   types.String  `tfsdk:"token"`
+	AuthProvider            types.String  `tfsdk:"auth_provider"`
+	DefaultEnv              types.String  `tfsdk:"default_env"`
+	Insecure                types.Bool    `tfsdk:"insecure"`
+	AllowUnauthenticated    types.Bool    `tfsdk:"allow_unauthenticated"`
+	MaxRetries              types.Int64   `tfsdk:"max_retries"`
+	RetryMinBackoff         types.String  `tfsdk:"retry_min_backoff"`
+	RetryMaxBackoff         types.String  `tfsdk:"retry_max_backoff"`
+	RetryOnPost             types.Bool    `tfsdk:"retry_on_post"`
+	ResponseCacheTTL        types.String  `tfsdk:"response_cache_ttl"`
+	MaxConcurrentRequests   types.Int64   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond       types.Float64 `tfsdk:"requests_per_second"`
+	MaxConcurrentImagePulls types.Int64   `tfsdk:"max_concurrent_image_pulls"`
 }
 
 func (p *dockhandProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -63,12 +73,17 @@ func (p *dockhandProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Dockhand API token sent as an `Authorization: Bearer` header instead of logging in. Conflicts with `username`/`password`. Can also be set with `DOCKHAND_TOKEN`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
 			"auth_provider": schema.StringAttribute{
 				MarkdownDescription: "Auth provider id for login-based auth (e.g. `local`). Can also be set with `DOCKHAND_AUTH_PROVIDER`.",
 				Optional:            true,
 			},
 			"default_env": schema.StringAttribute{
-				MarkdownDescription: "Default Dockhand environment ID sent as `env` query parameter when omitted by resources. Can also be set with `DOCKHAND_DEFAULT_ENV`.",
+				MarkdownDescription: "Default Dockhand environment ID or name sent as `env` query parameter when omitted by resources. Can also be set with `DOCKHAND_DEFAULT_ENV`.",
 				Optional:            true,
 			},
 			"insecure": schema.BoolAttribute{
@@ -79,6 +94,38 @@ func (p *dockhandProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 				MarkdownDescription: "Allow provider initialization without login credentials. Intended for first-install bootstrap flows (for example creating the initial admin user when Dockhand auth is disabled). Can also be set with `DOCKHAND_ALLOW_UNAUTHENTICATED`.",
 				Optional:            true,
 			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of retries for transient API failures (connection errors, `429`, `502`, `503`, `504`). Defaults to `2`; `0` disables retries.",
+				Optional:            true,
+			},
+			"retry_min_backoff": schema.StringAttribute{
+				MarkdownDescription: "Initial retry backoff as a Go duration (for example `200ms`). Doubles on each retry with jitter. Defaults to `200ms`.",
+				Optional:            true,
+			},
+			"retry_max_backoff": schema.StringAttribute{
+				MarkdownDescription: "Upper bound for the computed retry backoff as a Go duration. A longer `Retry-After` from the server is still honored. Defaults to `5s`.",
+				Optional:            true,
+			},
+			"retry_on_post": schema.BoolAttribute{
+				MarkdownDescription: "Also retry idempotent POST actions (container and stack start/stop, container pause/unpause). Other POSTs are never retried. Defaults to `false`.",
+				Optional:            true,
+			},
+			"response_cache_ttl": schema.StringAttribute{
+				MarkdownDescription: "Cache list responses (stacks, images, networks, volumes, containers, git stacks, schedules) per environment for this long, as a Go duration such as `5s`. Concurrent identical list requests are coalesced and any change made through the provider in the same environment invalidates the cache. Disabled by default.",
+				Optional:            true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of Dockhand API requests in flight at once, across all resources. Image pull and git deploy streams hold a slot until they finish. Unlimited by default.",
+				Optional:            true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum rate at which new Dockhand API requests are started, for example `5` or `0.5`. Retries count as requests. Unlimited by default.",
+				Optional:            true,
+			},
+			"max_concurrent_image_pulls": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of image pulls running at once per environment. Unlimited by default.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -110,6 +157,11 @@ func (p *dockhandProvider) Configure(ctx context.Context, req provider.Configure
 		mfaToken = config.MFAToken.ValueString()
 	}
 
+	token := os.Getenv("DOCKHAND_TOKEN")
+	if !config.Token.IsNull() && !config.Token.IsUnknown() {
+		token = config.Token.ValueString()
+	}
+
 	authProvider := os.Getenv("DOCKHAND_AUTH_PROVIDER")
 	if !config.AuthProvider.IsNull() && !config.AuthProvider.IsUnknown() {
 		authProvider = config.AuthProvider.ValueString()
@@ -128,6 +180,96 @@ func (p *dockhandProvider) Configure(ctx context.Context, req provider.Configure
 		insecure = config.Insecure.ValueBool()
 	}
 
+	retry := defaultRetryConfig()
+	if !config.MaxRetries.IsNull() && !config.MaxRetries.IsUnknown() {
+		if config.MaxRetries.ValueInt64() < 0 {
+			resp.Diagnostics.AddError(
+				"Invalid retry configuration",
+				"`max_retries` must be zero or greater.",
+			)
+			return
+		}
+		retry.maxRetries = int(config.MaxRetries.ValueInt64())
+	}
+	if !config.RetryMinBackoff.IsNull() && !config.RetryMinBackoff.IsUnknown() {
+		d, err := time.ParseDuration(config.RetryMinBackoff.ValueString())
+		if err != nil || d < 0 {
+			resp.Diagnostics.AddError(
+				"Invalid retry configuration",
+				fmt.Sprintf("`retry_min_backoff` must be a non-negative duration such as `200ms`, got %q.", config.RetryMinBackoff.ValueString()),
+			)
+			return
+		}
+		retry.minBackoff = d
+	}
+	if !config.RetryMaxBackoff.IsNull() && !config.RetryMaxBackoff.IsUnknown() {
+		d, err := time.ParseDuration(config.RetryMaxBackoff.ValueString())
+		if err != nil || d < 0 {
+			resp.Diagnostics.AddError(
+				"Invalid retry configuration",
+				fmt.Sprintf("`retry_max_backoff` must be a non-negative duration such as `5s`, got %q.", config.RetryMaxBackoff.ValueString()),
+			)
+			return
+		}
+		retry.maxBackoff = d
+	}
+	if retry.minBackoff > retry.maxBackoff {
+		resp.Diagnostics.AddError(
+			"Invalid retry configuration",
+			fmt.Sprintf("`retry_min_backoff` (%s) must not exceed `retry_max_backoff` (%s).", retry.minBackoff, retry.maxBackoff),
+		)
+		return
+	}
+	if !config.RetryOnPost.IsNull() && !config.RetryOnPost.IsUnknown() {
+		retry.retryOnPost = config.RetryOnPost.ValueBool()
+	}
+
+	var responseCacheTTL time.Duration
+	if !config.ResponseCacheTTL.IsNull() && !config.ResponseCacheTTL.IsUnknown() {
+		d, err := time.ParseDuration(config.ResponseCacheTTL.ValueString())
+		if err != nil || d < 0 {
+			resp.Diagnostics.AddError(
+				"Invalid response cache configuration",
+				fmt.Sprintf("`response_cache_ttl` must be a non-negative duration such as `5s`, got %q.", config.ResponseCacheTTL.ValueString()),
+			)
+			return
+		}
+		responseCacheTTL = d
+	}
+
+	var maxConcurrent, maxConcurrentPulls int
+	var requestsPerSecond float64
+	if !config.MaxConcurrentRequests.IsNull() && !config.MaxConcurrentRequests.IsUnknown() {
+		if config.MaxConcurrentRequests.ValueInt64() < 0 {
+			resp.Diagnostics.AddError(
+				"Invalid request limit configuration",
+				"`max_concurrent_requests` must be zero or greater.",
+			)
+			return
+		}
+		maxConcurrent = int(config.MaxConcurrentRequests.ValueInt64())
+	}
+	if !config.RequestsPerSecond.IsNull() && !config.RequestsPerSecond.IsUnknown() {
+		if config.RequestsPerSecond.ValueFloat64() < 0 {
+			resp.Diagnostics.AddError(
+				"Invalid request limit configuration",
+				"`requests_per_second` must be zero or greater.",
+			)
+			return
+		}
+		requestsPerSecond = config.RequestsPerSecond.ValueFloat64()
+	}
+	if !config.MaxConcurrentImagePulls.IsNull() && !config.MaxConcurrentImagePulls.IsUnknown() {
+		if config.MaxConcurrentImagePulls.ValueInt64() < 0 {
+			resp.Diagnostics.AddError(
+				"Invalid request limit configuration",
+				"`max_concurrent_image_pulls` must be zero or greater.",
+			)
+			return
+		}
+		maxConcurrentPulls = int(config.MaxConcurrentImagePulls.ValueInt64())
+	}
+
 	allowUnauthenticated := false
 	if raw := os.Getenv("DOCKHAND_ALLOW_UNAUTHENTICATED"); raw != "" {
 		switch raw {
@@ -148,11 +290,19 @@ func (p *dockhandProvider) Configure(ctx context.Context, req provider.Configure
 	}
 
 	sessionCookie := ""
-	if username == "" && password == "" {
+	if token != "" {
+		if username != "" || password != "" {
+			resp.Diagnostics.AddError(
+				"Conflicting Dockhand authentication",
+				"`token` cannot be combined with `username`/`password`. Configure either token-based or login-based auth.",
+			)
+			return
+		}
+	} else if username == "" && password == "" {
 		if !allowUnauthenticated {
 			resp.Diagnostics.AddError(
 				"Missing Dockhand authentication",
-				"Set provider `username` and `password` (or export `DOCKHAND_USERNAME`/`DOCKHAND_PASSWORD`). For first-install bootstrap flows, set `allow_unauthenticated = true` (or `DOCKHAND_ALLOW_UNAUTHENTICATED=true`).",
+				"Set provider `token` (or export `DOCKHAND_TOKEN`), or `username` and `password` (or export `DOCKHAND_USERNAME`/`DOCKHAND_PASSWORD`). For first-install bootstrap flows, set `allow_unauthenticated = true` (or `DOCKHAND_ALLOW_UNAUTHENTICATED=true`).",
 			)
 			return
 		}
@@ -191,6 +341,15 @@ func (p *dockhandProvider) Configure(ctx context.Context, req provider.Configure
 		)
 		return
 	}
+	client.SetRetryConfig(retry)
+	client.SetResponseCacheTTL(responseCacheTTL)
+	client.SetRequestLimits(maxConcurrent, requestsPerSecond, maxConcurrentPulls)
+	if token != "" {
+		client.SetAPIToken(token)
+	}
+	if sessionCookie != "" {
+		client.SetLoginCredentials(username, password, mfaToken, authProvider)
+	}
 
 	resp.ResourceData = client
 	resp.DataSourceData = client
@@ -216,6 +375,7 @@ func (p *dockhandProvider) Resources(_ context.Context) []func() resource.Resour
 		NewEnvironmentScannerActionResource,
 		NewNetworkResource,
 		NewNetworkConnectionActionResource,
+		NewNetworkAttachmentResource,
 		NewVolumeResource,
 		NewVolumeCloneActionResource,
 		NewImageResource,
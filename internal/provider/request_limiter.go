@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// requestLimiter bounds how many API requests are in flight and how fast new
+// ones start. The zero value does not limit anything.
+type requestLimiter struct {
+	// slots holds one token per running request; nil means unlimited.
+	slots chan struct{}
+
+	// interval is the minimum spacing between request starts; zero means no
+	// rate limit. next is the earliest start time of the next request.
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRequestLimiter(maxConcurrent int, requestsPerSecond float64) *requestLimiter {
+	l := &requestLimiter{}
+	if maxConcurrent > 0 {
+		l.slots = make(chan struct{}, maxConcurrent)
+	}
+	if requestsPerSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+	return l
+}
+
+// acquire waits for a free slot and the next rate-limited start time. The
+// returned release must be called once the response has been read.
+func (l *requestLimiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	release := func() {}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		var once sync.Once
+		release = func() { once.Do(func() { <-l.slots }) }
+	}
+
+	if wait := l.reserve(); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+	return release, nil
+}
+
+// reserve books the next start time and returns how long to wait for it.
+func (l *requestLimiter) reserve() time.Duration {
+	if l.interval <= 0 {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	start := l.next
+	if start.Before(now) {
+		start = now
+	}
+	l.next = start.Add(l.interval)
+	return start.Sub(now)
+}
+
+// keyedSemaphore limits concurrent work per key, for example image pulls per
+// environment. A non-positive size does not limit anything.
+type keyedSemaphore struct {
+	size  int
+	mu    sync.Mutex
+	slots map[string]chan struct{}
+}
+
+func (s *keyedSemaphore) acquire(ctx context.Context, key string) (func(), error) {
+	if s == nil || s.size <= 0 {
+		return func() {}, nil
+	}
+
+	s.mu.Lock()
+	if s.slots == nil {
+		s.slots = map[string]chan struct{}{}
+	}
+	slots, ok := s.slots[key]
+	if !ok {
+		slots = make(chan struct{}, s.size)
+		s.slots[key] = slots
+	}
+	s.mu.Unlock()
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// releaseOnClose runs release when a streamed response body is closed: it
+// frees the limiter slot, so long-running streams count against
+// `max_concurrent_requests`, and cancels the stream's default deadline.
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+}
+
+func (r *releaseOnClose) Close() error {
+	err := r.ReadCloser.Close()
+	r.release()
+	return err
+}
+
+// SetRequestLimits configures client-side concurrency and rate limits.
+// Zero values disable the corresponding limit.
+func (c *Client) SetRequestLimits(maxConcurrent int, requestsPerSecond float64, maxConcurrentPulls int) {
+	c.limiter = newRequestLimiter(maxConcurrent, requestsPerSecond)
+	c.pullSlots = &keyedSemaphore{size: maxConcurrentPulls}
+}
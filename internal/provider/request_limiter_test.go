@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRequestLimiterBoundsConcurrency(t *testing.T) {
+	t.Parallel()
+
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		inFlight.Add(-1)
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "", "1", true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	client.SetRequestLimits(2, 0, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := client.ListUsers(context.Background()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got != 2 {
+		t.Fatalf("expected at most 2 concurrent requests (and the limit reached), got %d", got)
+	}
+}
+
+func TestRequestLimiterSpacesRequests(t *testing.T) {
+	t.Parallel()
+
+	limiter := newRequestLimiter(0, 50)
+	started := time.Now()
+	for i := 0; i < 5; i++ {
+		release, err := limiter.acquire(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		release()
+	}
+	// Five starts at 50/s need at least four 20ms gaps.
+	if elapsed := time.Since(started); elapsed < 80*time.Millisecond {
+		t.Fatalf("expected requests to be spaced out, took %s", elapsed)
+	}
+}
+
+func TestRequestLimiterHonorsContext(t *testing.T) {
+	t.Parallel()
+
+	limiter := newRequestLimiter(1, 0)
+	release, err := limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.acquire(ctx); err == nil {
+		t.Fatal("expected acquire to fail when the context expires")
+	}
+}
+
+func TestKeyedSemaphoreLimitsPerKey(t *testing.T) {
+	t.Parallel()
+
+	sem := &keyedSemaphore{size: 1}
+	releaseA, err := sem.acquire(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Another environment is not blocked.
+	releaseB, err := sem.acquire(context.Background(), "2")
+	if err != nil {
+		t.Fatalf("expected a different key to proceed, got %v", err)
+	}
+	releaseB()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := sem.acquire(ctx, "1"); err == nil {
+		t.Fatal("expected second pull in the same environment to wait")
+	}
+
+	releaseA()
+	releaseA2, err := sem.acquire(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("expected slot after release, got %v", err)
+	}
+	releaseA2()
+}
+
+func TestStreamHoldsLimiterSlotUntilClosed(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"Pull complete"}` + "\n"))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "", "1", true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	client.SetRequestLimits(1, 0, 0)
+
+	if _, err := client.PullImage(context.Background(), "", "nginx:latest", false); err != nil {
+		t.Fatalf("unexpected pull error: %v", err)
+	}
+
+	// The slot must be free again once the stream was consumed.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	release, err := client.limiter.acquire(ctx)
+	if err != nil {
+		t.Fatalf("expected limiter slot to be released after the stream, got %v", err)
+	}
+	release()
+}
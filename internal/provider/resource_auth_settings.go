@@ -110,13 +110,13 @@ func (r *authSettingsResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
-	current, status, err := r.client.GetAuthSettings(ctx)
+	current, _, err := r.client.GetAuthSettings(ctx)
 	if err != nil {
-		if status == 404 {
+		if IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading Dockhand auth settings", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error reading Dockhand auth settings", err)
 		return
 	}
 
@@ -157,7 +157,7 @@ func (r *authSettingsResource) applyPlan(ctx context.Context, plan authSettingsM
 
 	current, _, err := r.client.GetAuthSettings(ctx)
 	if err != nil {
-		diags.AddError("Error reading Dockhand auth settings", err.Error())
+		addAPIError(&diags, path.Empty(), "Error reading Dockhand auth settings", err)
 		return authSettingsModel{}, diags
 	}
 
@@ -179,7 +179,7 @@ func (r *authSettingsResource) applyPlan(ctx context.Context, plan authSettingsM
 
 	updated, _, err := r.client.UpdateAuthSettings(ctx, payload)
 	if err != nil {
-		diags.AddError("Error updating Dockhand auth settings", err.Error())
+		addAPIError(&diags, path.Empty(), "Error updating Dockhand auth settings", err)
 		return authSettingsModel{}, diags
 	}
 
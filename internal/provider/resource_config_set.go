@@ -174,7 +174,7 @@ func (r *configSetResource) Create(ctx context.Context, req resource.CreateReque
 
 	created, _, err := r.client.CreateConfigSet(ctx, payload)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating Dockhand config set", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("name"), "Error creating Dockhand config set", err)
 		return
 	}
 
@@ -195,13 +195,13 @@ func (r *configSetResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	cs, status, err := r.client.GetConfigSet(ctx, state.ID.ValueString())
+	cs, _, err := r.client.GetConfigSet(ctx, state.ID.ValueString())
 	if err != nil {
-		if status == 404 {
+		if IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading Dockhand config set", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error reading Dockhand config set", err)
 		return
 	}
 
@@ -238,7 +238,7 @@ func (r *configSetResource) Update(ctx context.Context, req resource.UpdateReque
 
 	updated, _, err := r.client.UpdateConfigSet(ctx, id, payload)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating Dockhand config set", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("name"), "Error updating Dockhand config set", err)
 		return
 	}
 
@@ -259,9 +259,9 @@ func (r *configSetResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	status, err := r.client.DeleteConfigSet(ctx, state.ID.ValueString())
-	if err != nil && status != 404 {
-		resp.Diagnostics.AddError("Error deleting Dockhand config set", err.Error())
+	_, err := r.client.DeleteConfigSet(ctx, state.ID.ValueString())
+	if err != nil && !IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error deleting Dockhand config set", err)
 		return
 	}
 }
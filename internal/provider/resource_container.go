@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -16,6 +19,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -41,35 +45,87 @@ type containerPortModel struct {
 	Protocol      types.String `tfsdk:"protocol"`
 }
 
+type containerMountModel struct {
+	Type           types.String                 `tfsdk:"type"`
+	Source         types.String                 `tfsdk:"source"`
+	Target         types.String                 `tfsdk:"target"`
+	ReadOnly       types.Bool                   `tfsdk:"read_only"`
+	VolumeOptions  *containerVolumeOptionsModel `tfsdk:"volume_options"`
+	TmpfsSizeBytes types.Int64                  `tfsdk:"tmpfs_size_bytes"`
+}
+
+type containerVolumeOptionsModel struct {
+	NoCopy        types.Bool   `tfsdk:"no_copy"`
+	Labels        types.Map    `tfsdk:"labels"`
+	Driver        types.String `tfsdk:"driver"`
+	DriverOptions types.Map    `tfsdk:"driver_options"`
+}
+
+type containerNetworkModel struct {
+	Network     types.String `tfsdk:"network"`
+	Aliases     types.Set    `tfsdk:"aliases"`
+	IPv4Address types.String `tfsdk:"ipv4_address"`
+	IPv6Address types.String `tfsdk:"ipv6_address"`
+}
+
+type containerImagePullModel struct {
+	Policy     types.String `tfsdk:"policy"`
+	RegistryID types.Int64  `tfsdk:"registry_id"`
+	Platform   types.String `tfsdk:"platform"`
+}
+
+type containerHealthcheckModel struct {
+	Test        types.List   `tfsdk:"test"`
+	Interval    types.String `tfsdk:"interval"`
+	Timeout     types.String `tfsdk:"timeout"`
+	Retries     types.Int64  `tfsdk:"retries"`
+	StartPeriod types.String `tfsdk:"start_period"`
+}
+
 type containerResourceModel struct {
-	ID            types.String         `tfsdk:"id"`
-	Name          types.String         `tfsdk:"name"`
-	Env           types.String         `tfsdk:"env"`
-	Image         types.String         `tfsdk:"image"`
-	Command       types.String         `tfsdk:"command"`
-	Enabled       types.Bool           `tfsdk:"enabled"`
-	NetworkMode   types.String         `tfsdk:"network_mode"`
-	RestartPolicy types.String         `tfsdk:"restart_policy"`
-	Privileged    types.Bool           `tfsdk:"privileged"`
-	TTY           types.Bool           `tfsdk:"tty"`
-	MemoryBytes   types.Int64          `tfsdk:"memory_bytes"`
-	NanoCPUs      types.Int64          `tfsdk:"nano_cpus"`
-	CapAdd        types.List           `tfsdk:"cap_add"`
-	EnvVars       types.Map            `tfsdk:"env_vars"`
-	Labels        types.Map            `tfsdk:"labels"`
-	Ports         []containerPortModel `tfsdk:"ports"`
-	UpdatePayload types.String         `tfsdk:"update_payload_json"`
-	State         types.String         `tfsdk:"state"`
-	Status        types.String         `tfsdk:"status"`
-	Health        types.String         `tfsdk:"health"`
-	RestartCount  types.Int64          `tfsdk:"restart_count"`
+	ID            types.String               `tfsdk:"id"`
+	Name          types.String               `tfsdk:"name"`
+	Env           types.String               `tfsdk:"env"`
+	Image         types.String               `tfsdk:"image"`
+	ImagePull     *containerImagePullModel   `tfsdk:"image_pull"`
+	Command       types.String               `tfsdk:"command"`
+	Enabled       types.Bool                 `tfsdk:"enabled"`
+	NetworkMode   types.String               `tfsdk:"network_mode"`
+	RestartPolicy types.String               `tfsdk:"restart_policy"`
+	Privileged    types.Bool                 `tfsdk:"privileged"`
+	TTY           types.Bool                 `tfsdk:"tty"`
+	MemoryBytes   types.Int64                `tfsdk:"memory_bytes"`
+	NanoCPUs      types.Int64                `tfsdk:"nano_cpus"`
+	CapAdd        types.List                 `tfsdk:"cap_add"`
+	EnvVars       types.Map                  `tfsdk:"env_vars"`
+	Labels        types.Map                  `tfsdk:"labels"`
+	Ports         []containerPortModel       `tfsdk:"ports"`
+	Mounts        []containerMountModel      `tfsdk:"mounts"`
+	Networks      []containerNetworkModel    `tfsdk:"networks"`
+	Healthcheck   *containerHealthcheckModel `tfsdk:"healthcheck"`
+	WaitHealthy   types.Bool                 `tfsdk:"wait_for_healthy"`
+	UpdatePayload types.String               `tfsdk:"update_payload_json"`
+	State         types.String               `tfsdk:"state"`
+	Status        types.String               `tfsdk:"status"`
+	Health        types.String               `tfsdk:"health"`
+	RestartCount  types.Int64                `tfsdk:"restart_count"`
+	Timeouts      timeouts.Value             `tfsdk:"timeouts"`
 }
 
+const (
+	defaultContainerCreateTimeout = 5 * time.Minute
+	defaultContainerUpdateTimeout = 5 * time.Minute
+	defaultContainerDeleteTimeout = 2 * time.Minute
+
+	containerHealthPollInterval = 2 * time.Second
+	containerFailureLogTail     = 50
+)
+
 func (r *containerResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_container"
 }
 
-func (r *containerResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *containerResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages a Dockhand container using `/api/containers` endpoints.",
 		Attributes: map[string]schema.Attribute{
@@ -85,7 +141,7 @@ func (r *containerResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"env": schema.StringAttribute{
-				MarkdownDescription: "Dockhand environment ID sent as the `env` query parameter.",
+				MarkdownDescription: "Dockhand environment ID or name sent as the `env` query parameter.",
 				Optional:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
@@ -119,11 +175,8 @@ func (r *containerResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"restart_policy": schema.StringAttribute{
-				MarkdownDescription: "Restart policy for create request (for example `no`, `unless-stopped`).",
+				MarkdownDescription: "Restart policy (for example `no`, `unless-stopped`). Changes are applied in place through `/api/containers/{id}/update`; removing it resets the policy to `no`.",
 				Optional:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"privileged": schema.BoolAttribute{
 				MarkdownDescription: "Whether to create the container in privileged mode.",
@@ -140,17 +193,17 @@ func (r *containerResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"memory_bytes": schema.Int64Attribute{
-				MarkdownDescription: "Container memory limit in bytes.",
+				MarkdownDescription: "Container memory limit in bytes. Changes are applied in place; removing the limit recreates the container.",
 				Optional:            true,
 				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.RequiresReplace(),
+					int64planmodifier.RequiresReplaceIf(int64RemovedRequiresReplace, "Docker cannot remove a resource limit from an existing container.", "Docker cannot remove a resource limit from an existing container."),
 				},
 			},
 			"nano_cpus": schema.Int64Attribute{
-				MarkdownDescription: "CPU quota in NanoCPUs (for example `500000000` = 0.5 CPU).",
+				MarkdownDescription: "CPU quota in NanoCPUs (for example `500000000` = 0.5 CPU). Changes are applied in place; removing the quota recreates the container.",
 				Optional:            true,
 				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.RequiresReplace(),
+					int64planmodifier.RequiresReplaceIf(int64RemovedRequiresReplace, "Docker cannot remove a resource limit from an existing container.", "Docker cannot remove a resource limit from an existing container."),
 				},
 			},
 			"cap_add": schema.ListAttribute{
@@ -197,6 +250,112 @@ func (r *containerResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					},
 				},
 			},
+			"mounts": schema.ListNestedAttribute{
+				MarkdownDescription: "Volume, bind and tmpfs mounts for create request. Docker cannot change mounts on an existing container, so changes recreate it. Refreshed from container inspect to detect drift.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Mount type: `volume`, `bind` or `tmpfs`.",
+							Required:            true,
+						},
+						"source": schema.StringAttribute{
+							MarkdownDescription: "Volume name for `volume` mounts or absolute host path for `bind` mounts. Not used for `tmpfs`.",
+							Optional:            true,
+						},
+						"target": schema.StringAttribute{
+							MarkdownDescription: "Absolute path inside the container.",
+							Required:            true,
+						},
+						"read_only": schema.BoolAttribute{
+							MarkdownDescription: "Mount read-only. Defaults to `false`.",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(false),
+						},
+						"volume_options": schema.SingleNestedAttribute{
+							MarkdownDescription: "Options for `volume` mounts, used when Docker creates the volume.",
+							Optional:            true,
+							Attributes: map[string]schema.Attribute{
+								"no_copy": schema.BoolAttribute{
+									MarkdownDescription: "Do not populate the volume with data from the image.",
+									Optional:            true,
+								},
+								"labels": schema.MapAttribute{
+									MarkdownDescription: "Labels set on a newly created volume.",
+									Optional:            true,
+									ElementType:         types.StringType,
+								},
+								"driver": schema.StringAttribute{
+									MarkdownDescription: "Volume driver name.",
+									Optional:            true,
+								},
+								"driver_options": schema.MapAttribute{
+									MarkdownDescription: "Volume driver options.",
+									Optional:            true,
+									ElementType:         types.StringType,
+								},
+							},
+						},
+						"tmpfs_size_bytes": schema.Int64Attribute{
+							MarkdownDescription: "Size limit for `tmpfs` mounts in bytes.",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"image_pull": schema.SingleNestedAttribute{
+				MarkdownDescription: "Pull `image` before the container is created, with registry credentials and platform selection. Only used at create time.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"policy": schema.StringAttribute{
+						MarkdownDescription: "`missing` (default) pulls only when the image is not present in the environment; `always` pulls on every create.",
+						Optional:            true,
+					},
+					"registry_id": schema.Int64Attribute{
+						MarkdownDescription: "ID of the `dockhand_registry` whose credentials are used for the pull.",
+						Optional:            true,
+					},
+					"platform": schema.StringAttribute{
+						MarkdownDescription: "Platform to pull, for example `linux/arm64`.",
+						Optional:            true,
+					},
+				},
+			},
+			"networks": schema.SetNestedAttribute{
+				MarkdownDescription: "Additional networks the container is attached to, on top of `network_mode`. Attachments are connected after create and reconciled in place: changed or removed entries are disconnected and reconnected without recreating the container.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"network": schema.StringAttribute{
+							MarkdownDescription: "Network ID or name.",
+							Required:            true,
+						},
+						"aliases": schema.SetAttribute{
+							MarkdownDescription: "DNS aliases of the container on this network.",
+							Optional:            true,
+							ElementType:         types.StringType,
+						},
+						"ipv4_address": schema.StringAttribute{
+							MarkdownDescription: "Static IPv4 address on this network. The network must have a user-defined subnet.",
+							Optional:            true,
+						},
+						"ipv6_address": schema.StringAttribute{
+							MarkdownDescription: "Static IPv6 address on this network.",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"wait_for_healthy": schema.BoolAttribute{
+				MarkdownDescription: "Wait after create until the container reports `healthy` (or `running` when it has no healthcheck). The wait is bounded by `timeouts.create`; the apply fails with the last container logs when the container turns `unhealthy` or exits.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 			"update_payload_json": schema.StringAttribute{
 				MarkdownDescription: "Optional raw JSON object sent to `/api/containers/{id}/update` after create and on updates. Use this to access advanced Dockhand update fields not yet modeled as first-class attributes.",
 				Optional:            true,
@@ -219,6 +378,42 @@ func (r *containerResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Computed:            true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"healthcheck": schema.SingleNestedBlock{
+				MarkdownDescription: "Container healthcheck, overriding the one defined by the image. Changes recreate the container.",
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"test": schema.ListAttribute{
+						MarkdownDescription: "Healthcheck command in Docker form, for example `[\"CMD-SHELL\", \"curl -f http://localhost/ || exit 1\"]` or `[\"NONE\"]` to disable the image healthcheck.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"interval": schema.StringAttribute{
+						MarkdownDescription: "Time between checks as a Go duration (for example `30s`).",
+						Optional:            true,
+					},
+					"timeout": schema.StringAttribute{
+						MarkdownDescription: "Time a single check may take as a Go duration.",
+						Optional:            true,
+					},
+					"retries": schema.Int64Attribute{
+						MarkdownDescription: "Consecutive failures needed to report `unhealthy`.",
+						Optional:            true,
+					},
+					"start_period": schema.StringAttribute{
+						MarkdownDescription: "Initialization time during which failures do not count, as a Go duration.",
+						Optional:            true,
+					},
+				},
+			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -247,6 +442,14 @@ func (r *containerResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultContainerCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	payload := containerPayload{
 		Name:   plan.Name.ValueString(),
 		Image:  plan.Image.ValueString(),
@@ -278,10 +481,36 @@ func (r *containerResource) Create(ctx context.Context, req resource.CreateReque
 	if v := flattenStringList(ctx, plan.CapAdd); len(v) > 0 {
 		payload.CapAdd = v
 	}
+	mounts, err := flattenContainerMounts(ctx, plan.Mounts)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("mounts"), "Invalid container mounts", err.Error())
+		return
+	}
+	payload.Volumes = mounts
+	healthcheck, err := buildContainerHealthcheckPayload(ctx, plan.Healthcheck)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("healthcheck"), "Invalid container healthcheck", err.Error())
+		return
+	}
+	payload.Healthcheck = healthcheck
+
+	if plan.ImagePull != nil {
+		pullPayload, pull, err := r.containerImagePull(ctx, plan.Env.ValueString(), plan.Image.ValueString(), plan.ImagePull)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("image_pull"), "Invalid container image pull", err.Error())
+			return
+		}
+		if pull {
+			if _, err := r.client.PullImageWithOptions(ctx, plan.Env.ValueString(), pullPayload); err != nil {
+				addAPIError(&resp.Diagnostics, path.Root("image_pull"), "Error pulling Dockhand container image", err)
+				return
+			}
+		}
+	}
 
 	created, _, err := r.client.CreateContainer(ctx, plan.Env.ValueString(), payload)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating Dockhand container", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error creating Dockhand container", err)
 		return
 	}
 	if created == nil || strings.TrimSpace(created.ID) == "" {
@@ -294,14 +523,13 @@ func (r *containerResource) Create(ctx context.Context, req resource.CreateReque
 	if payloadRaw := strings.TrimSpace(plan.UpdatePayload.ValueString()); payloadRaw != "" {
 		updatePayload, parseErr := parseContainerUpdatePayload(payloadRaw)
 		if parseErr != nil {
-			resp.Diagnostics.AddError("Invalid `update_payload_json`", parseErr.Error())
+			r.saveCreatedContainer(ctx, &plan, resp)
+			resp.Diagnostics.AddAttributeError(path.Root("update_payload_json"), "Invalid `update_payload_json`", parseErr.Error())
 			return
 		}
-		if _, status, err := r.client.UpdateContainer(ctx, plan.Env.ValueString(), created.ID, updatePayload); err != nil {
-			resp.Diagnostics.AddError("Error applying Dockhand container update payload", err.Error())
-			return
-		} else if status < 200 || status > 299 {
-			resp.Diagnostics.AddError("Error applying Dockhand container update payload", fmt.Sprintf("Dockhand returned status %d", status))
+		if _, _, err := r.client.UpdateContainer(ctx, plan.Env.ValueString(), created.ID, updatePayload); err != nil {
+			r.saveCreatedContainer(ctx, &plan, resp)
+			addAPIError(&resp.Diagnostics, path.Root("update_payload_json"), "Error applying Dockhand container update payload", err)
 			return
 		}
 		plan.UpdatePayload = types.StringValue(payloadRaw)
@@ -309,16 +537,32 @@ func (r *containerResource) Create(ctx context.Context, req resource.CreateReque
 		plan.UpdatePayload = types.StringNull()
 	}
 
+	for _, network := range plan.Networks {
+		if _, err := r.client.ConnectNetworkEndpoint(ctx, plan.Env.ValueString(), network.Network.ValueString(), buildContainerNetworkPayload(ctx, created.ID, network)); err != nil {
+			r.saveCreatedContainer(ctx, &plan, resp)
+			addAPIError(&resp.Diagnostics, path.Root("networks"), fmt.Sprintf("Error connecting Dockhand container to network %q", network.Network.ValueString()), err)
+			return
+		}
+	}
+
 	if !plan.Enabled.ValueBool() {
 		if _, err := r.client.StopContainer(ctx, plan.Env.ValueString(), created.ID); err != nil {
-			resp.Diagnostics.AddError("Error stopping Dockhand container after create", err.Error())
+			r.saveCreatedContainer(ctx, &plan, resp)
+			addAPIError(&resp.Diagnostics, path.Root("enabled"), "Error stopping Dockhand container after create", err)
+			return
+		}
+	} else if plan.WaitHealthy.ValueBool() {
+		if err := waitForContainerHealthy(ctx, r.client, plan.Env.ValueString(), created.ID); err != nil {
+			r.saveCreatedContainer(ctx, &plan, resp)
+			resp.Diagnostics.AddAttributeError(path.Root("wait_for_healthy"), "Dockhand container did not become healthy", err.Error())
 			return
 		}
 	}
 
 	container, found, err := r.client.GetContainerByID(ctx, plan.Env.ValueString(), created.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Dockhand container after create", err.Error())
+		r.saveCreatedContainer(ctx, &plan, resp)
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error reading Dockhand container after create", err)
 		return
 	}
 	if found {
@@ -328,6 +572,21 @@ func (r *containerResource) Create(ctx context.Context, req resource.CreateReque
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
+// saveCreatedContainer records a container that already exists in Docker
+// when a later create step fails, so the failed create taints it and the
+// next apply replaces it instead of leaving it orphaned.
+func (r *containerResource) saveCreatedContainer(ctx context.Context, plan *containerResourceModel, resp *resource.CreateResponse) {
+	if plan.UpdatePayload.IsUnknown() {
+		plan.UpdatePayload = types.StringNull()
+	}
+	if container, found, err := r.client.GetContainerByID(context.WithoutCancel(ctx), plan.Env.ValueString(), plan.ID.ValueString()); err == nil && found {
+		applyContainerRuntimeToState(plan, container)
+	} else {
+		plan.State, plan.Status, plan.Health, plan.RestartCount = types.StringNull(), types.StringNull(), types.StringNull(), types.Int64Null()
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
 func (r *containerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	if r.client == nil {
 		resp.Diagnostics.AddError("Unconfigured client", "The provider client was not configured.")
@@ -340,17 +599,30 @@ func (r *containerResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	container, found, err := r.client.GetContainerByID(ctx, state.Env.ValueString(), state.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error reading Dockhand container", err.Error())
+	// A single inspect call provides both runtime state and configuration,
+	// so a container recreated by hand with other settings shows up as drift.
+	inspect, _, err := r.client.InspectContainer(ctx, state.Env.ValueString(), state.ID.ValueString())
+	if IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
 		return
 	}
-	if !found {
-		resp.State.RemoveResource(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error reading Dockhand container", err)
 		return
 	}
 
-	applyContainerRuntimeToState(&state, container)
+	applyContainerRuntimeToState(&state, inspect.summary())
+	applyContainerInspectToState(ctx, &state, inspect)
+
+	// Mounts and network attachments that were never configured stay null so
+	// image-defined volumes and default networks do not show up as drift.
+	if state.Mounts != nil {
+		state.Mounts = refreshContainerMounts(state.Mounts, inspect)
+	}
+	if state.Networks != nil {
+		state.Networks = refreshContainerNetworks(ctx, state.Networks, inspect)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -368,6 +640,14 @@ func (r *containerResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultContainerUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	id := state.ID.ValueString()
 	env := plan.Env.ValueString()
 	if plan.Enabled.ValueBool() != state.Enabled.ValueBool() {
@@ -378,7 +658,28 @@ func (r *containerResource) Update(ctx context.Context, req resource.UpdateReque
 			_, err = r.client.StopContainer(ctx, env, id)
 		}
 		if err != nil {
-			resp.Diagnostics.AddError("Error updating Dockhand container runtime state", err.Error())
+			addAPIError(&resp.Diagnostics, path.Root("enabled"), "Error updating Dockhand container runtime state", err)
+			return
+		}
+	}
+
+	disconnect, connect := diffContainerNetworks(ctx, state.Networks, plan.Networks)
+	for _, network := range disconnect {
+		if _, err := r.client.DisconnectNetwork(ctx, env, network.Network.ValueString(), id); err != nil && !IsNotFound(err) {
+			addAPIError(&resp.Diagnostics, path.Root("networks"), fmt.Sprintf("Error disconnecting Dockhand container from network %q", network.Network.ValueString()), err)
+			return
+		}
+	}
+	for _, network := range connect {
+		if _, err := r.client.ConnectNetworkEndpoint(ctx, env, network.Network.ValueString(), buildContainerNetworkPayload(ctx, id, network)); err != nil {
+			addAPIError(&resp.Diagnostics, path.Root("networks"), fmt.Sprintf("Error connecting Dockhand container to network %q", network.Network.ValueString()), err)
+			return
+		}
+	}
+
+	if livePayload := buildContainerLiveUpdatePayload(plan, state); len(livePayload) > 0 {
+		if _, _, err := r.client.UpdateContainer(ctx, env, id, livePayload); err != nil {
+			addAPIError(&resp.Diagnostics, path.Empty(), "Error updating Dockhand container settings", err)
 			return
 		}
 	}
@@ -387,15 +688,12 @@ func (r *containerResource) Update(ctx context.Context, req resource.UpdateReque
 	if payloadRaw != "" {
 		updatePayload, parseErr := parseContainerUpdatePayload(payloadRaw)
 		if parseErr != nil {
-			resp.Diagnostics.AddError("Invalid `update_payload_json`", parseErr.Error())
+			resp.Diagnostics.AddAttributeError(path.Root("update_payload_json"), "Invalid `update_payload_json`", parseErr.Error())
 			return
 		}
 		if payloadRaw != strings.TrimSpace(state.UpdatePayload.ValueString()) {
-			if _, status, err := r.client.UpdateContainer(ctx, env, id, updatePayload); err != nil {
-				resp.Diagnostics.AddError("Error applying Dockhand container update payload", err.Error())
-				return
-			} else if status < 200 || status > 299 {
-				resp.Diagnostics.AddError("Error applying Dockhand container update payload", fmt.Sprintf("Dockhand returned status %d", status))
+			if _, _, err := r.client.UpdateContainer(ctx, env, id, updatePayload); err != nil {
+				addAPIError(&resp.Diagnostics, path.Root("update_payload_json"), "Error applying Dockhand container update payload", err)
 				return
 			}
 		}
@@ -406,7 +704,7 @@ func (r *containerResource) Update(ctx context.Context, req resource.UpdateReque
 
 	container, found, err := r.client.GetContainerByID(ctx, env, id)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Dockhand container after update", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error reading Dockhand container after update", err)
 		return
 	}
 	if !found {
@@ -431,8 +729,16 @@ func (r *containerResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	status, err := r.client.DeleteContainer(ctx, state.Env.ValueString(), state.ID.ValueString())
-	if err != nil && status != 404 {
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultContainerDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	_, err := r.client.DeleteContainer(ctx, state.Env.ValueString(), state.ID.ValueString())
+	if err != nil && !IsNotFound(err) {
 		// Some Dockhand builds return 500 "Failed to remove container" when the
 		// container is already gone. Re-check existence before failing destroy.
 		_, found, readErr := r.client.GetContainerByID(ctx, state.Env.ValueString(), state.ID.ValueString())
@@ -441,16 +747,26 @@ func (r *containerResource) Delete(ctx context.Context, req resource.DeleteReque
 			return
 		}
 		if found {
-			resp.Diagnostics.AddError("Error deleting Dockhand container", err.Error())
+			addAPIError(&resp.Diagnostics, path.Root("id"), "Error deleting Dockhand container", err)
 			return
 		}
 	}
 
 	// Dockhand may briefly report containers as "marked for removal". Wait until
 	// the container no longer appears before allowing dependent deletes (images).
-	for range 30 {
+	timedOut := func() {
+		resp.Diagnostics.AddError(
+			"Timed out waiting for Dockhand container deletion",
+			fmt.Sprintf("Container %q still appears after delete (timeout %s); retry destroy or raise `timeouts.delete`.", state.ID.ValueString(), deleteTimeout),
+		)
+	}
+	for {
 		_, found, readErr := r.client.GetContainerByID(ctx, state.Env.ValueString(), state.ID.ValueString())
 		if readErr != nil {
+			if ctx.Err() != nil {
+				timedOut()
+				return
+			}
 			resp.Diagnostics.AddError("Error confirming Dockhand container deletion", readErr.Error())
 			return
 		}
@@ -459,16 +775,11 @@ func (r *containerResource) Delete(ctx context.Context, req resource.DeleteReque
 		}
 		select {
 		case <-ctx.Done():
-			resp.Diagnostics.AddError("Error confirming Dockhand container deletion", ctx.Err().Error())
+			timedOut()
 			return
 		case <-time.After(1 * time.Second):
 		}
 	}
-
-	resp.Diagnostics.AddError(
-		"Timed out waiting for Dockhand container deletion",
-		fmt.Sprintf("Container %q still appears after delete; retry destroy.", state.ID.ValueString()),
-	)
 }
 
 func (r *containerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -506,6 +817,300 @@ func applyContainerRuntimeToState(state *containerResourceModel, container *cont
 	state.RestartCount = types.Int64Value(container.RestartCount)
 }
 
+// applyContainerInspectToState refreshes the configuration attributes from
+// container inspect. Unset optional attributes stay null while the live value
+// is Docker's default, and only env and label keys configured in Terraform
+// are compared: entries inherited from the image or added by Compose and
+// Dockhand are not drift.
+func applyContainerInspectToState(ctx context.Context, state *containerResourceModel, inspect *containerInspectResponse) {
+	if inspect == nil {
+		return
+	}
+
+	if live := strings.TrimSpace(inspect.Config.Image); live != "" &&
+		(state.Image.IsNull() || normalizeImageReference(state.Image.ValueString()) != normalizeImageReference(live)) {
+		state.Image = types.StringValue(live)
+	}
+	if !state.Command.IsNull() && len(inspect.Config.Cmd) > 0 && !containerCommandMatches(state.Command.ValueString(), inspect.Config.Cmd) {
+		state.Command = types.StringValue(strings.Join(inspect.Config.Cmd, " "))
+	}
+
+	state.EnvVars = refreshManagedStringMap(ctx, state.EnvVars, parseContainerEnv(inspect.Config.Env))
+	state.Labels = refreshManagedStringMap(ctx, state.Labels, inspect.Config.Labels)
+
+	if !state.Privileged.IsNull() || inspect.HostConfig.Privileged {
+		state.Privileged = types.BoolValue(inspect.HostConfig.Privileged)
+	}
+	state.MemoryBytes = refreshContainerLimit(state.MemoryBytes, inspect.HostConfig.Memory)
+	state.NanoCPUs = refreshContainerLimit(state.NanoCPUs, inspect.HostConfig.NanoCPUs)
+	state.Ports = refreshContainerPorts(state.Ports, inspect.HostConfig.PortBindings)
+}
+
+// containerCommandMatches reports whether the configured command string
+// produced the container's Cmd, either split on whitespace or wrapped in a
+// shell.
+func containerCommandMatches(command string, cmd []string) bool {
+	if strings.Join(strings.Fields(command), " ") == strings.Join(cmd, " ") {
+		return true
+	}
+	return len(cmd) == 3 && strings.HasSuffix(cmd[0], "sh") && cmd[1] == "-c" && strings.TrimSpace(cmd[2]) == strings.TrimSpace(command)
+}
+
+func parseContainerEnv(env []string) map[string]string {
+	out := make(map[string]string, len(env))
+	for _, entry := range env {
+		key, value, _ := strings.Cut(entry, "=")
+		if key != "" {
+			out[key] = value
+		}
+	}
+	return out
+}
+
+// refreshManagedStringMap updates the configured keys from live values.
+// Keys missing on the container are dropped; unconfigured live keys are
+// ignored.
+func refreshManagedStringMap(ctx context.Context, configured types.Map, live map[string]string) types.Map {
+	values := flattenStringMap(ctx, configured)
+	if values == nil {
+		return configured
+	}
+	elems := make(map[string]attr.Value, len(values))
+	for key := range values {
+		if v, ok := live[key]; ok {
+			elems[key] = types.StringValue(v)
+		}
+	}
+	return types.MapValueMust(types.StringType, elems)
+}
+
+// refreshContainerLimit treats 0 as "no limit", which is what Docker reports
+// for containers created without one.
+func refreshContainerLimit(configured types.Int64, live int64) types.Int64 {
+	if live == 0 && configured.IsNull() {
+		return configured
+	}
+	return types.Int64Value(live)
+}
+
+// refreshContainerPorts rebuilds port mappings from HostConfig.PortBindings.
+// Configured ports keep their order and protocol spelling; bindings missing
+// on the container are dropped and unexpected ones appended in sorted order.
+func refreshContainerPorts(configured []containerPortModel, bindings map[string][]containerInspectPortBinding) []containerPortModel {
+	if len(bindings) == 0 {
+		if configured == nil {
+			return nil
+		}
+		return []containerPortModel{}
+	}
+
+	out := make([]containerPortModel, 0, len(bindings))
+	matched := map[string]bool{}
+	for _, p := range configured {
+		protocol := strings.ToLower(strings.TrimSpace(p.Protocol.ValueString()))
+		if protocol == "" {
+			protocol = "tcp"
+		}
+		key := fmt.Sprintf("%d/%s", p.ContainerPort.ValueInt64(), protocol)
+		live, ok := bindings[key]
+		if !ok {
+			continue
+		}
+		matched[key] = true
+		if len(live) > 0 && live[0].HostPort != "" {
+			p.HostPort = types.StringValue(live[0].HostPort)
+		}
+		out = append(out, p)
+	}
+
+	keys := make([]string, 0, len(bindings))
+	for key := range bindings {
+		if !matched[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		portRaw, protocol, _ := strings.Cut(key, "/")
+		port, err := strconv.ParseInt(portRaw, 10, 64)
+		if err != nil {
+			continue
+		}
+		hostPort := ""
+		if live := bindings[key]; len(live) > 0 {
+			hostPort = live[0].HostPort
+		}
+		model := containerPortModel{
+			ContainerPort: types.Int64Value(port),
+			HostPort:      types.StringValue(hostPort),
+			Protocol:      types.StringValue(protocol),
+		}
+		if protocol == "" || protocol == "tcp" {
+			model.Protocol = types.StringNull()
+		}
+		out = append(out, model)
+	}
+	return out
+}
+
+// buildContainerLiveUpdatePayload returns the Docker update fields that differ
+// between state and plan. Only settings Docker can change on an existing
+// container belong here; everything else is marked RequiresReplace.
+func buildContainerLiveUpdatePayload(plan containerResourceModel, state containerResourceModel) map[string]any {
+	out := map[string]any{}
+
+	if !plan.MemoryBytes.IsNull() && !plan.MemoryBytes.IsUnknown() && !plan.MemoryBytes.Equal(state.MemoryBytes) {
+		out["Memory"] = plan.MemoryBytes.ValueInt64()
+	}
+	if !plan.NanoCPUs.IsNull() && !plan.NanoCPUs.IsUnknown() && !plan.NanoCPUs.Equal(state.NanoCPUs) {
+		out["NanoCpus"] = plan.NanoCPUs.ValueInt64()
+	}
+	if !plan.RestartPolicy.IsUnknown() && strings.TrimSpace(plan.RestartPolicy.ValueString()) != strings.TrimSpace(state.RestartPolicy.ValueString()) {
+		name := strings.TrimSpace(plan.RestartPolicy.ValueString())
+		if name == "" {
+			name = "no"
+		}
+		out["RestartPolicy"] = map[string]any{
+			"Name": name,
+		}
+	}
+
+	return out
+}
+
+// containerImagePull builds the pull request for `image_pull` and reports
+// whether the policy requires a pull.
+func (r *containerResource) containerImagePull(ctx context.Context, env string, image string, opts *containerImagePullModel) (imagePullPayload, bool, error) {
+	payload := imagePullPayload{
+		Image:      image,
+		RegistryID: int64PtrFromInt64Value(opts.RegistryID),
+		Platform:   strings.TrimSpace(opts.Platform.ValueString()),
+	}
+	if payload.RegistryID != nil && *payload.RegistryID <= 0 {
+		return payload, false, fmt.Errorf("`registry_id` must be a positive integer")
+	}
+
+	policy := strings.ToLower(strings.TrimSpace(opts.Policy.ValueString()))
+	switch policy {
+	case "", "missing":
+		images, _, err := r.client.ListImages(ctx, env)
+		if err != nil {
+			return payload, false, fmt.Errorf("checking whether image %q is present: %w", image, err)
+		}
+		return payload, !imagePresent(images, image), nil
+	case "always":
+		return payload, true, nil
+	default:
+		return payload, false, fmt.Errorf("`policy` must be `missing` or `always`, got %q", opts.Policy.ValueString())
+	}
+}
+
+func buildContainerHealthcheckPayload(ctx context.Context, hc *containerHealthcheckModel) (*containerHealthcheckPayload, error) {
+	if hc == nil {
+		return nil, nil
+	}
+	test := flattenStringList(ctx, hc.Test)
+	if len(test) == 0 {
+		return nil, fmt.Errorf("`test` is required, for example [\"CMD-SHELL\", \"curl -f http://localhost/ || exit 1\"]")
+	}
+
+	out := &containerHealthcheckPayload{Test: test}
+	durations := []struct {
+		name  string
+		value types.String
+		dest  *int64
+	}{
+		{"interval", hc.Interval, &out.Interval},
+		{"timeout", hc.Timeout, &out.Timeout},
+		{"start_period", hc.StartPeriod, &out.StartPeriod},
+	}
+	for _, d := range durations {
+		raw := strings.TrimSpace(d.value.ValueString())
+		if raw == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed < 0 {
+			return nil, fmt.Errorf("`%s` must be a non-negative duration such as `30s`, got %q", d.name, raw)
+		}
+		*d.dest = parsed.Nanoseconds()
+	}
+	if !hc.Retries.IsNull() && !hc.Retries.IsUnknown() {
+		if hc.Retries.ValueInt64() < 0 {
+			return nil, fmt.Errorf("`retries` must be zero or greater")
+		}
+		out.Retries = hc.Retries.ValueInt64()
+	}
+	return out, nil
+}
+
+// waitForContainerHealthy polls the container until it reports healthy, or
+// running when it has no healthcheck. It fails fast when the container turns
+// unhealthy or exits, and gives up when ctx expires; both errors carry the
+// tail of the container logs.
+func waitForContainerHealthy(ctx context.Context, client *Client, env string, id string) error {
+	var last *containerResponse
+	for {
+		container, found, err := client.GetContainerByID(ctx, env, id)
+		if err != nil {
+			if ctx.Err() != nil {
+				return containerNotHealthyError(ctx, client, env, id, last, "timed out waiting for the container to become healthy")
+			}
+			return err
+		}
+		if !found {
+			return fmt.Errorf("container %s disappeared while waiting for it to become healthy", id)
+		}
+		last = container
+
+		health := strings.ToLower(strings.TrimSpace(container.Health))
+		state := strings.ToLower(strings.TrimSpace(container.State))
+		switch {
+		case health == "healthy":
+			return nil
+		case health == "unhealthy":
+			return containerNotHealthyError(ctx, client, env, id, last, "container reported unhealthy")
+		case state == "exited" || state == "dead":
+			return containerNotHealthyError(ctx, client, env, id, last, "container exited")
+		case (health == "" || health == "none") && state == "running":
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return containerNotHealthyError(ctx, client, env, id, last, "timed out waiting for the container to become healthy")
+		case <-time.After(containerHealthPollInterval):
+		}
+	}
+}
+
+func containerNotHealthyError(ctx context.Context, client *Client, env string, id string, last *containerResponse, reason string) error {
+	var b strings.Builder
+	b.WriteString(reason)
+	if last != nil {
+		fmt.Fprintf(&b, " (state %q, health %q, status %q, restarts %d)", last.State, last.Health, last.Status, last.RestartCount)
+	}
+	b.WriteString(".")
+
+	// The operation context may already be expired; logs get a short budget of their own.
+	logCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 15*time.Second)
+	defer cancel()
+	logs, _, err := client.GetContainerLogs(logCtx, env, id, containerFailureLogTail)
+	switch {
+	case err != nil:
+		fmt.Fprintf(&b, "\n\nContainer logs are unavailable: %s", err)
+	case logs == nil || strings.TrimSpace(logs.Logs) == "":
+		b.WriteString("\n\nThe container produced no logs.")
+	default:
+		fmt.Fprintf(&b, "\n\nLast %d log lines:\n%s", containerFailureLogTail, strings.TrimRight(logs.Logs, "\n"))
+	}
+	return fmt.Errorf("%s", b.String())
+}
+
+func int64RemovedRequiresReplace(_ context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = req.PlanValue.IsNull() && !req.StateValue.IsNull()
+}
+
 func parseContainerUpdatePayload(raw string) (map[string]any, error) {
 	payload := map[string]any{}
 	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
@@ -569,6 +1174,274 @@ func flattenContainerPorts(values []containerPortModel) []containerPortPayload {
 	return out
 }
 
+func flattenContainerMounts(ctx context.Context, values []containerMountModel) ([]containerMountPayload, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	out := make([]containerMountPayload, 0, len(values))
+	for i, m := range values {
+		mountType := strings.TrimSpace(m.Type.ValueString())
+		source := strings.TrimSpace(m.Source.ValueString())
+		target := strings.TrimSpace(m.Target.ValueString())
+		if target == "" {
+			return nil, fmt.Errorf("mounts[%d]: target is required", i)
+		}
+
+		item := containerMountPayload{
+			Source:   source,
+			Target:   target,
+			Type:     mountType,
+			ReadOnly: m.ReadOnly.ValueBool(),
+		}
+		switch mountType {
+		case "volume", "bind":
+			if source == "" {
+				return nil, fmt.Errorf("mounts[%d]: source is required for %s mounts", i, mountType)
+			}
+			if mountType == "bind" && !strings.HasPrefix(source, "/") {
+				return nil, fmt.Errorf("mounts[%d]: bind source must be an absolute host path, got %q", i, source)
+			}
+			if !m.TmpfsSizeBytes.IsNull() {
+				return nil, fmt.Errorf("mounts[%d]: tmpfs_size_bytes is only valid for tmpfs mounts", i)
+			}
+		case "tmpfs":
+			if source != "" {
+				return nil, fmt.Errorf("mounts[%d]: source is not used for tmpfs mounts", i)
+			}
+			if v := int64PtrFromInt64Value(m.TmpfsSizeBytes); v != nil {
+				item.TmpfsOptions = &containerTmpfsOptionsPayload{SizeBytes: *v}
+			}
+		default:
+			return nil, fmt.Errorf("mounts[%d]: type must be one of volume, bind or tmpfs, got %q", i, mountType)
+		}
+
+		if opts := m.VolumeOptions; opts != nil {
+			if mountType != "volume" {
+				return nil, fmt.Errorf("mounts[%d]: volume_options is only valid for volume mounts", i)
+			}
+			item.VolumeOptions = &containerVolumeOptionsPayload{
+				NoCopy: opts.NoCopy.ValueBool(),
+				Labels: flattenStringMap(ctx, opts.Labels),
+			}
+			if driver := strings.TrimSpace(opts.Driver.ValueString()); driver != "" {
+				item.VolumeOptions.DriverConfig = &containerVolumeDriverPayload{
+					Name:    driver,
+					Options: flattenStringMap(ctx, opts.DriverOptions),
+				}
+			}
+		}
+
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+// refreshContainerMounts rebuilds the configured mounts from inspect data.
+// Mounts are matched by target; configured mounts missing on the container
+// are dropped and unexpected ones appended, so both show up as drift.
+// Anonymous volumes created from image VOLUME directives are ignored.
+// Options Docker does not report back (volume options, tmpfs size) are kept.
+func refreshContainerMounts(configured []containerMountModel, inspect *containerInspectResponse) []containerMountModel {
+	if inspect == nil {
+		return configured
+	}
+
+	type actualMount struct {
+		mountType string
+		source    string
+		readOnly  bool
+	}
+	actual := map[string]actualMount{}
+	var order []string
+	for _, m := range inspect.Mounts {
+		if m.Destination == "" {
+			continue
+		}
+		source := m.Source
+		if m.Type == "volume" {
+			source = m.Name
+		}
+		if _, seen := actual[m.Destination]; !seen {
+			order = append(order, m.Destination)
+		}
+		actual[m.Destination] = actualMount{mountType: m.Type, source: source, readOnly: !m.RW}
+	}
+	for _, m := range inspect.HostConfig.Mounts {
+		if _, seen := actual[m.Target]; seen || m.Target == "" {
+			continue
+		}
+		order = append(order, m.Target)
+		actual[m.Target] = actualMount{mountType: m.Type, source: m.Source, readOnly: m.ReadOnly}
+	}
+
+	out := make([]containerMountModel, 0, len(actual))
+	matched := map[string]bool{}
+	for _, m := range configured {
+		target := m.Target.ValueString()
+		found, ok := actual[target]
+		if !ok {
+			continue
+		}
+		matched[target] = true
+		m.Type = types.StringValue(found.mountType)
+		if found.mountType == "tmpfs" {
+			m.Source = types.StringNull()
+		} else {
+			m.Source = types.StringValue(found.source)
+		}
+		m.ReadOnly = types.BoolValue(found.readOnly)
+		out = append(out, m)
+	}
+	for _, target := range order {
+		found := actual[target]
+		if matched[target] || (found.mountType == "volume" && isAnonymousVolumeName(found.source)) {
+			continue
+		}
+		m := containerMountModel{
+			Type:           types.StringValue(found.mountType),
+			Source:         types.StringValue(found.source),
+			Target:         types.StringValue(target),
+			ReadOnly:       types.BoolValue(found.readOnly),
+			TmpfsSizeBytes: types.Int64Null(),
+		}
+		if found.mountType == "tmpfs" {
+			m.Source = types.StringNull()
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+func buildContainerNetworkPayload(ctx context.Context, containerID string, network containerNetworkModel) networkContainerPayload {
+	payload := networkContainerPayload{
+		ContainerID: containerID,
+		Aliases:     flattenStringSet(ctx, network.Aliases),
+		IPv4Address: strings.TrimSpace(network.IPv4Address.ValueString()),
+		IPv6Address: strings.TrimSpace(network.IPv6Address.ValueString()),
+	}
+	sort.Strings(payload.Aliases)
+	return payload
+}
+
+// containerNetworkKey identifies an attachment together with its endpoint
+// settings, so a changed alias or address counts as a different attachment.
+func containerNetworkKey(ctx context.Context, network containerNetworkModel) string {
+	payload := buildContainerNetworkPayload(ctx, "", network)
+	return strings.Join([]string{network.Network.ValueString(), strings.Join(payload.Aliases, ","), payload.IPv4Address, payload.IPv6Address}, "|")
+}
+
+// diffContainerNetworks returns the attachments to disconnect (present in
+// state but not in the plan) and to connect (planned but not in state).
+// Attachments whose settings changed appear in both and are reconnected.
+func diffContainerNetworks(ctx context.Context, state []containerNetworkModel, plan []containerNetworkModel) ([]containerNetworkModel, []containerNetworkModel) {
+	planned := map[string]bool{}
+	for _, network := range plan {
+		planned[containerNetworkKey(ctx, network)] = true
+	}
+	current := map[string]bool{}
+	var disconnect []containerNetworkModel
+	for _, network := range state {
+		key := containerNetworkKey(ctx, network)
+		current[key] = true
+		if !planned[key] {
+			disconnect = append(disconnect, network)
+		}
+	}
+	var connect []containerNetworkModel
+	for _, network := range plan {
+		if !current[containerNetworkKey(ctx, network)] {
+			connect = append(connect, network)
+		}
+	}
+	return disconnect, connect
+}
+
+// refreshContainerNetworks reconciles configured attachments with the
+// inspect payload. Attachments that disappeared are dropped, aliases that
+// are missing are removed and static addresses are replaced by the ones
+// Docker reports, so the next plan reconnects them. Networks attached outside
+// this resource are ignored.
+func refreshContainerNetworks(ctx context.Context, configured []containerNetworkModel, inspect *containerInspectResponse) []containerNetworkModel {
+	if inspect == nil {
+		return configured
+	}
+
+	out := make([]containerNetworkModel, 0, len(configured))
+	for _, network := range configured {
+		endpoint, ok := findContainerEndpoint(inspect.NetworkSettings.Networks, network.Network.ValueString())
+		if !ok {
+			continue
+		}
+
+		network.Aliases = keepConfiguredAliases(ctx, network.Aliases, endpoint.Aliases)
+
+		var ipv4, ipv6 string
+		if endpoint.IPAMConfig != nil {
+			ipv4, ipv6 = endpoint.IPAMConfig.IPv4Address, endpoint.IPAMConfig.IPv6Address
+		}
+		if network.IPv4Address.ValueString() != "" && network.IPv4Address.ValueString() != ipv4 {
+			network.IPv4Address = types.StringValue(ipv4)
+		}
+		if network.IPv6Address.ValueString() != "" && network.IPv6Address.ValueString() != ipv6 {
+			network.IPv6Address = types.StringValue(ipv6)
+		}
+		out = append(out, network)
+	}
+	return out
+}
+
+// keepConfiguredAliases narrows configured to the aliases Docker still
+// reports. Docker adds its own aliases (such as the short container ID), so
+// unconfigured ones are never copied into state.
+func keepConfiguredAliases(ctx context.Context, configured types.Set, actual []string) types.Set {
+	if configured.IsNull() || configured.IsUnknown() {
+		return configured
+	}
+	present := map[string]bool{}
+	for _, alias := range actual {
+		present[alias] = true
+	}
+	var kept []attr.Value
+	for _, alias := range flattenStringSet(ctx, configured) {
+		if present[alias] {
+			kept = append(kept, types.StringValue(alias))
+		}
+	}
+	return types.SetValueMust(types.StringType, kept)
+}
+
+// findContainerEndpoint looks up an attachment by network name, full ID or
+// short ID prefix.
+func findContainerEndpoint(endpoints map[string]containerInspectEndpoint, network string) (containerInspectEndpoint, bool) {
+	if endpoint, ok := endpoints[network]; ok {
+		return endpoint, true
+	}
+	if network == "" {
+		return containerInspectEndpoint{}, false
+	}
+	for _, endpoint := range endpoints {
+		if endpoint.NetworkID == network || (len(network) >= 12 && strings.HasPrefix(endpoint.NetworkID, network)) {
+			return endpoint, true
+		}
+	}
+	return containerInspectEndpoint{}, false
+}
+
+// isAnonymousVolumeName reports whether name looks like a Docker-generated
+// anonymous volume (64 hex characters).
+func isAnonymousVolumeName(name string) bool {
+	if len(name) != 64 {
+		return false
+	}
+	for _, r := range name {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
 func stringPtrFromStringValue(value types.String) *string {
 	if value.IsNull() || value.IsUnknown() {
 		return nil
@@ -607,3 +1480,15 @@ func flattenStringList(ctx context.Context, value types.List) []string {
 	}
 	return out
 }
+
+func flattenStringSet(ctx context.Context, value types.Set) []string {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	out := []string{}
+	diags := value.ElementsAs(ctx, &out, false)
+	if diags.HasError() {
+		return nil
+	}
+	return out
+}
@@ -122,7 +122,7 @@ func (r *containerActionResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Error running Dockhand container action", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error running Dockhand container action", err)
 		return
 	}
 	if status < 200 || status > 299 {
@@ -86,7 +86,7 @@ func (r *containerCheckUpdatesActionResource) Create(ctx context.Context, req re
 
 	out, status, err := r.client.CheckContainerUpdates(ctx, plan.Env.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error checking container updates", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error checking container updates", err)
 		return
 	}
 	if status < 200 || status > 299 {
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -130,7 +131,7 @@ func (r *containerFileResource) Create(ctx context.Context, req resource.CreateR
 
 	status, err := r.client.CreateContainerFile(ctx, env, containerID, filePath, entryType)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating Dockhand container file", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error creating Dockhand container file", err)
 		return
 	}
 	if status < 200 || status > 299 {
@@ -141,7 +142,7 @@ func (r *containerFileResource) Create(ctx context.Context, req resource.CreateR
 	if entryType == "file" {
 		status, err = r.client.UpdateContainerFileContent(ctx, env, containerID, filePath, content)
 		if err != nil {
-			resp.Diagnostics.AddError("Error writing Dockhand container file", err.Error())
+			addAPIError(&resp.Diagnostics, path.Empty(), "Error writing Dockhand container file", err)
 			return
 		}
 		if status < 200 || status > 299 {
@@ -177,7 +178,7 @@ func (r *containerFileResource) Read(ctx context.Context, req resource.ReadReque
 				resp.State.RemoveResource(ctx)
 				return
 			}
-			resp.Diagnostics.AddError("Error reading Dockhand container file", err.Error())
+			addAPIError(&resp.Diagnostics, path.Root("id"), "Error reading Dockhand container file", err)
 			return
 		}
 		if status == http.StatusNotFound {
@@ -222,7 +223,7 @@ func (r *containerFileResource) Update(ctx context.Context, req resource.UpdateR
 		}
 		status, err := r.client.UpdateContainerFileContent(ctx, plan.Env.ValueString(), plan.ContainerID.ValueString(), plan.Path.ValueString(), content)
 		if err != nil {
-			resp.Diagnostics.AddError("Error updating Dockhand container file", err.Error())
+			addAPIError(&resp.Diagnostics, path.Empty(), "Error updating Dockhand container file", err)
 			return
 		}
 		if status < 200 || status > 299 {
@@ -251,8 +252,8 @@ func (r *containerFileResource) Delete(ctx context.Context, req resource.DeleteR
 	}
 
 	status, err := r.client.DeleteContainerFile(ctx, state.Env.ValueString(), state.ContainerID.ValueString(), state.Path.ValueString())
-	if err != nil && status != http.StatusNotFound {
-		resp.Diagnostics.AddError("Error deleting Dockhand container file", err.Error())
+	if err != nil && !IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error deleting Dockhand container file", err)
 		return
 	}
 	if status != 0 && status != http.StatusNotFound && (status < 200 || status > 299) {
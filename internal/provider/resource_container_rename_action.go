@@ -101,7 +101,7 @@ func (r *containerRenameActionResource) Create(ctx context.Context, req resource
 
 	status, err := r.client.RenameContainer(ctx, plan.Env.ValueString(), containerID, name)
 	if err != nil {
-		resp.Diagnostics.AddError("Error renaming Dockhand container", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error renaming Dockhand container", err)
 		return
 	}
 	if status < 200 || status > 299 {
@@ -1,6 +1,13 @@
 package provider
 
-import "testing"
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 func TestParseContainerUpdatePayload(t *testing.T) {
 	t.Run("valid object", func(t *testing.T) {
@@ -19,3 +26,316 @@ func TestParseContainerUpdatePayload(t *testing.T) {
 		}
 	})
 }
+
+func TestBuildContainerLiveUpdatePayload(t *testing.T) {
+	t.Run("unchanged fields are omitted", func(t *testing.T) {
+		state := containerResourceModel{
+			MemoryBytes:   types.Int64Value(268435456),
+			NanoCPUs:      types.Int64Null(),
+			RestartPolicy: types.StringValue("unless-stopped"),
+		}
+		if out := buildContainerLiveUpdatePayload(state, state); len(out) != 0 {
+			t.Fatalf("expected empty payload, got %#v", out)
+		}
+	})
+
+	t.Run("changed limits and policy", func(t *testing.T) {
+		state := containerResourceModel{
+			MemoryBytes:   types.Int64Value(268435456),
+			NanoCPUs:      types.Int64Null(),
+			RestartPolicy: types.StringValue("unless-stopped"),
+		}
+		plan := containerResourceModel{
+			MemoryBytes:   types.Int64Value(536870912),
+			NanoCPUs:      types.Int64Value(500000000),
+			RestartPolicy: types.StringNull(),
+		}
+
+		out := buildContainerLiveUpdatePayload(plan, state)
+		if out["Memory"] != int64(536870912) {
+			t.Fatalf("expected Memory, got %#v", out["Memory"])
+		}
+		if out["NanoCpus"] != int64(500000000) {
+			t.Fatalf("expected NanoCpus, got %#v", out["NanoCpus"])
+		}
+		restart, ok := out["RestartPolicy"].(map[string]any)
+		if !ok || restart["Name"] != "no" {
+			t.Fatalf("expected RestartPolicy reset to no, got %#v", out["RestartPolicy"])
+		}
+	})
+}
+
+func TestFlattenContainerMounts(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("valid mounts", func(t *testing.T) {
+		got, err := flattenContainerMounts(ctx, []containerMountModel{
+			{Type: types.StringValue("volume"), Source: types.StringValue("data"), Target: types.StringValue("/data"), ReadOnly: types.BoolValue(false), VolumeOptions: &containerVolumeOptionsModel{NoCopy: types.BoolValue(true), Driver: types.StringValue("local"), Labels: types.MapNull(types.StringType), DriverOptions: types.MapNull(types.StringType)}},
+			{Type: types.StringValue("bind"), Source: types.StringValue("/srv/conf"), Target: types.StringValue("/etc/app"), ReadOnly: types.BoolValue(true)},
+			{Type: types.StringValue("tmpfs"), Source: types.StringNull(), Target: types.StringValue("/tmp"), TmpfsSizeBytes: types.Int64Value(1 << 20)},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != 3 {
+			t.Fatalf("expected 3 mounts, got %d", len(got))
+		}
+		if got[0].VolumeOptions == nil || !got[0].VolumeOptions.NoCopy || got[0].VolumeOptions.DriverConfig == nil || got[0].VolumeOptions.DriverConfig.Name != "local" {
+			t.Fatalf("unexpected volume options: %+v", got[0].VolumeOptions)
+		}
+		if !got[1].ReadOnly || got[1].Source != "/srv/conf" {
+			t.Fatalf("unexpected bind mount: %+v", got[1])
+		}
+		if got[2].TmpfsOptions == nil || got[2].TmpfsOptions.SizeBytes != 1<<20 {
+			t.Fatalf("unexpected tmpfs mount: %+v", got[2])
+		}
+	})
+
+	invalid := map[string]containerMountModel{
+		"unknown type":         {Type: types.StringValue("npipe"), Target: types.StringValue("/x")},
+		"volume without name":  {Type: types.StringValue("volume"), Target: types.StringValue("/x")},
+		"relative bind":        {Type: types.StringValue("bind"), Source: types.StringValue("./conf"), Target: types.StringValue("/x")},
+		"tmpfs with source":    {Type: types.StringValue("tmpfs"), Source: types.StringValue("/tmp"), Target: types.StringValue("/x")},
+		"bind volume options":  {Type: types.StringValue("bind"), Source: types.StringValue("/srv"), Target: types.StringValue("/x"), VolumeOptions: &containerVolumeOptionsModel{}},
+		"volume with tmpfs sz": {Type: types.StringValue("volume"), Source: types.StringValue("data"), Target: types.StringValue("/x"), TmpfsSizeBytes: types.Int64Value(1)},
+	}
+	for name, mount := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := flattenContainerMounts(ctx, []containerMountModel{mount}); err == nil {
+				t.Fatalf("expected validation error")
+			}
+		})
+	}
+}
+
+func TestRefreshContainerMounts(t *testing.T) {
+	configured := []containerMountModel{
+		{Type: types.StringValue("volume"), Source: types.StringValue("data"), Target: types.StringValue("/data"), ReadOnly: types.BoolValue(false), TmpfsSizeBytes: types.Int64Null()},
+		{Type: types.StringValue("bind"), Source: types.StringValue("/srv/conf"), Target: types.StringValue("/etc/app"), ReadOnly: types.BoolValue(true), TmpfsSizeBytes: types.Int64Null()},
+		{Type: types.StringValue("tmpfs"), Source: types.StringNull(), Target: types.StringValue("/tmp"), ReadOnly: types.BoolValue(false), TmpfsSizeBytes: types.Int64Value(1024)},
+	}
+	inspect := &containerInspectResponse{
+		Mounts: []containerInspectMount{
+			{Type: "volume", Name: "data", Source: "/var/lib/docker/volumes/data/_data", Destination: "/data", RW: false},
+			{Type: "volume", Name: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef", Destination: "/var/cache", RW: true},
+			{Type: "bind", Source: "/srv/extra", Destination: "/extra", RW: true},
+		},
+		HostConfig: containerInspectHostConfig{
+			Mounts: []containerInspectMountSpec{{Type: "tmpfs", Target: "/tmp"}},
+		},
+	}
+
+	got := refreshContainerMounts(configured, inspect)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 mounts (bind dropped, extra bind added), got %d: %+v", len(got), got)
+	}
+	if got[0].Target.ValueString() != "/data" || !got[0].ReadOnly.ValueBool() {
+		t.Fatalf("expected read-only drift on /data, got %+v", got[0])
+	}
+	if got[1].Target.ValueString() != "/tmp" || got[1].TmpfsSizeBytes.ValueInt64() != 1024 || !got[1].Source.IsNull() {
+		t.Fatalf("expected tmpfs mount with preserved size, got %+v", got[1])
+	}
+	if got[2].Target.ValueString() != "/extra" || got[2].Source.ValueString() != "/srv/extra" {
+		t.Fatalf("expected unmanaged bind to be reported, got %+v", got[2])
+	}
+}
+
+func TestBuildContainerHealthcheckPayload(t *testing.T) {
+	ctx := context.Background()
+	test := types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("CMD-SHELL"),
+		types.StringValue("curl -f http://localhost/ || exit 1"),
+	})
+
+	payload, err := buildContainerHealthcheckPayload(ctx, &containerHealthcheckModel{
+		Test:        test,
+		Interval:    types.StringValue("30s"),
+		Timeout:     types.StringValue("5s"),
+		Retries:     types.Int64Value(3),
+		StartPeriod: types.StringNull(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(payload.Test) != 2 || payload.Test[0] != "CMD-SHELL" {
+		t.Fatalf("unexpected test: %#v", payload.Test)
+	}
+	if payload.Interval != int64(30*time.Second) || payload.Timeout != int64(5*time.Second) || payload.StartPeriod != 0 {
+		t.Fatalf("unexpected durations: %#v", payload)
+	}
+	if payload.Retries != 3 {
+		t.Fatalf("expected 3 retries, got %d", payload.Retries)
+	}
+
+	if payload, err := buildContainerHealthcheckPayload(ctx, nil); err != nil || payload != nil {
+		t.Fatalf("expected no healthcheck for nil block, got %#v, %v", payload, err)
+	}
+
+	if _, err := buildContainerHealthcheckPayload(ctx, &containerHealthcheckModel{
+		Test:     test,
+		Interval: types.StringValue("thirty"),
+	}); err == nil {
+		t.Fatalf("expected invalid interval error")
+	}
+
+	if _, err := buildContainerHealthcheckPayload(ctx, &containerHealthcheckModel{
+		Test: types.ListNull(types.StringType),
+	}); err == nil {
+		t.Fatalf("expected missing test error")
+	}
+}
+
+func TestDiffContainerNetworks(t *testing.T) {
+	ctx := context.Background()
+	aliases := func(values ...string) types.Set {
+		elems := make([]attr.Value, 0, len(values))
+		for _, v := range values {
+			elems = append(elems, types.StringValue(v))
+		}
+		return types.SetValueMust(types.StringType, elems)
+	}
+	backend := containerNetworkModel{Network: types.StringValue("backend"), Aliases: aliases("api"), IPv4Address: types.StringNull(), IPv6Address: types.StringNull()}
+	backendRenamed := containerNetworkModel{Network: types.StringValue("backend"), Aliases: aliases("api", "web"), IPv4Address: types.StringNull(), IPv6Address: types.StringNull()}
+	monitoring := containerNetworkModel{Network: types.StringValue("monitoring"), Aliases: types.SetNull(types.StringType), IPv4Address: types.StringValue("10.9.0.5"), IPv6Address: types.StringNull()}
+	frontend := containerNetworkModel{Network: types.StringValue("frontend"), Aliases: types.SetNull(types.StringType), IPv4Address: types.StringNull(), IPv6Address: types.StringNull()}
+
+	disconnect, connect := diffContainerNetworks(ctx,
+		[]containerNetworkModel{backend, monitoring},
+		[]containerNetworkModel{backendRenamed, monitoring, frontend},
+	)
+	if len(disconnect) != 1 || disconnect[0].Network.ValueString() != "backend" {
+		t.Fatalf("unexpected disconnects: %#v", disconnect)
+	}
+	if len(connect) != 2 || connect[0].Network.ValueString() != "backend" || connect[1].Network.ValueString() != "frontend" {
+		t.Fatalf("unexpected connects: %#v", connect)
+	}
+
+	if disconnect, connect := diffContainerNetworks(ctx, []containerNetworkModel{backend}, []containerNetworkModel{backend}); len(disconnect) != 0 || len(connect) != 0 {
+		t.Fatalf("expected no changes, got %#v / %#v", disconnect, connect)
+	}
+}
+
+func TestRefreshContainerNetworks(t *testing.T) {
+	ctx := context.Background()
+	configured := []containerNetworkModel{
+		{
+			Network:     types.StringValue("backend"),
+			Aliases:     types.SetValueMust(types.StringType, []attr.Value{types.StringValue("api"), types.StringValue("web")}),
+			IPv4Address: types.StringValue("10.1.0.10"),
+			IPv6Address: types.StringNull(),
+		},
+		{
+			Network:     types.StringValue("0123456789abcdef"),
+			Aliases:     types.SetNull(types.StringType),
+			IPv4Address: types.StringNull(),
+			IPv6Address: types.StringNull(),
+		},
+		{
+			Network:     types.StringValue("gone"),
+			Aliases:     types.SetNull(types.StringType),
+			IPv4Address: types.StringNull(),
+			IPv6Address: types.StringNull(),
+		},
+	}
+	inspect := &containerInspectResponse{
+		NetworkSettings: containerInspectNetworkSettings{
+			Networks: map[string]containerInspectEndpoint{
+				"backend": {
+					NetworkID:  "ffff",
+					Aliases:    []string{"api", "3f2a1b"},
+					IPAMConfig: &containerInspectIPAMConfig{IPv4Address: "10.1.0.99"},
+				},
+				"monitoring": {NetworkID: "0123456789abcdef0123456789abcdef"},
+				"bridge":     {NetworkID: "bbbb"},
+			},
+		},
+	}
+
+	refreshed := refreshContainerNetworks(ctx, configured, inspect)
+	if len(refreshed) != 2 {
+		t.Fatalf("expected 2 attachments, got %#v", refreshed)
+	}
+	if got := flattenStringSet(ctx, refreshed[0].Aliases); len(got) != 1 || got[0] != "api" {
+		t.Fatalf("expected missing alias to be dropped, got %#v", got)
+	}
+	if refreshed[0].IPv4Address.ValueString() != "10.1.0.99" {
+		t.Fatalf("expected actual address, got %q", refreshed[0].IPv4Address.ValueString())
+	}
+	if refreshed[1].Network.ValueString() != "0123456789abcdef" {
+		t.Fatalf("expected attachment matched by ID prefix, got %#v", refreshed[1])
+	}
+}
+
+func TestApplyContainerInspectToState(t *testing.T) {
+	ctx := context.Background()
+	state := containerResourceModel{
+		Image:       types.StringValue("nginx:1.27"),
+		Command:     types.StringValue("nginx -g 'daemon off;'"),
+		Privileged:  types.BoolNull(),
+		MemoryBytes: types.Int64Value(268435456),
+		NanoCPUs:    types.Int64Null(),
+		EnvVars: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"MODE":  types.StringValue("prod"),
+			"TOKEN": types.StringValue("abc"),
+		}),
+		Labels: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"team": types.StringValue("web"),
+		}),
+		Ports: []containerPortModel{
+			{ContainerPort: types.Int64Value(80), HostPort: types.StringValue("8080"), Protocol: types.StringNull()},
+			{ContainerPort: types.Int64Value(53), HostPort: types.StringValue("5353"), Protocol: types.StringValue("udp")},
+		},
+	}
+	inspect := &containerInspectResponse{
+		Config: containerInspectConfig{
+			Image: "docker.io/library/nginx:1.27",
+			Cmd:   []string{"/bin/sh", "-c", "nginx -g 'daemon off;'"},
+			Env:   []string{"PATH=/usr/local/sbin:/usr/bin", "NGINX_VERSION=1.27.0", "MODE=dev"},
+			Labels: map[string]string{
+				"team":                       "web",
+				"maintainer":                 "NGINX Docker Maintainers",
+				"com.docker.compose.project": "web",
+			},
+		},
+		HostConfig: containerInspectHostConfig{
+			Memory: 536870912,
+			PortBindings: map[string][]containerInspectPortBinding{
+				"80/tcp":  {{HostPort: "8080"}},
+				"443/tcp": {{HostIP: "0.0.0.0", HostPort: "8443"}},
+			},
+		},
+	}
+
+	applyContainerInspectToState(ctx, &state, inspect)
+
+	if state.Image.ValueString() != "nginx:1.27" {
+		t.Fatalf("expected equivalent image reference to be kept, got %q", state.Image.ValueString())
+	}
+	if state.Command.ValueString() != "nginx -g 'daemon off;'" {
+		t.Fatalf("expected shell-wrapped command to match, got %q", state.Command.ValueString())
+	}
+	if !state.Privileged.IsNull() || !state.NanoCPUs.IsNull() {
+		t.Fatalf("expected unset defaults to stay null, got privileged=%v nano_cpus=%v", state.Privileged, state.NanoCPUs)
+	}
+	if state.MemoryBytes.ValueInt64() != 536870912 {
+		t.Fatalf("expected memory drift, got %d", state.MemoryBytes.ValueInt64())
+	}
+	env := flattenStringMap(ctx, state.EnvVars)
+	if len(env) != 1 || env["MODE"] != "dev" {
+		t.Fatalf("expected changed MODE and dropped TOKEN without image env, got %#v", env)
+	}
+	labels := flattenStringMap(ctx, state.Labels)
+	if len(labels) != 1 || labels["team"] != "web" {
+		t.Fatalf("expected injected and image labels to be ignored, got %#v", labels)
+	}
+	if len(state.Ports) != 2 || state.Ports[0].HostPort.ValueString() != "8080" || state.Ports[1].ContainerPort.ValueInt64() != 443 || !state.Ports[1].Protocol.IsNull() {
+		t.Fatalf("expected udp port dropped and 443 appended, got %+v", state.Ports)
+	}
+
+	inspect.Config.Image = "nginx:1.28"
+	inspect.HostConfig.Privileged = true
+	applyContainerInspectToState(ctx, &state, inspect)
+	if state.Image.ValueString() != "nginx:1.28" || !state.Privileged.ValueBool() {
+		t.Fatalf("expected image and privileged drift, got image=%q privileged=%v", state.Image.ValueString(), state.Privileged)
+	}
+}
@@ -148,7 +148,7 @@ func (r *containerUpdateActionResource) Create(ctx context.Context, req resource
 
 	result, status, err := r.client.UpdateContainer(ctx, plan.Env.ValueString(), containerID, payload)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating Dockhand container", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error updating Dockhand container", err)
 		return
 	}
 	if status < 200 || status > 299 {
@@ -261,7 +261,7 @@ func (r *environmentResource) Create(ctx context.Context, req resource.CreateReq
 
 	created, _, err := r.client.CreateEnvironment(ctx, payload)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating Dockhand environment", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("name"), "Error creating Dockhand environment", err)
 		return
 	}
 
@@ -283,13 +283,13 @@ func (r *environmentResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	env, status, err := r.client.GetEnvironment(ctx, state.ID.ValueString())
+	env, _, err := r.client.GetEnvironment(ctx, state.ID.ValueString())
 	if err != nil {
-		if status == 404 {
+		if IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading Dockhand environment", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error reading Dockhand environment", err)
 		return
 	}
 
@@ -326,7 +326,7 @@ func (r *environmentResource) Update(ctx context.Context, req resource.UpdateReq
 
 	updated, _, err := r.client.UpdateEnvironment(ctx, id, payload)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating Dockhand environment", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("name"), "Error updating Dockhand environment", err)
 		return
 	}
 
@@ -348,9 +348,9 @@ func (r *environmentResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
-	status, err := r.client.DeleteEnvironment(ctx, state.ID.ValueString())
-	if err != nil && status != 404 {
-		resp.Diagnostics.AddError("Error deleting Dockhand environment", err.Error())
+	_, err := r.client.DeleteEnvironment(ctx, state.ID.ValueString())
+	if err != nil && !IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error deleting Dockhand environment", err)
 		return
 	}
 }
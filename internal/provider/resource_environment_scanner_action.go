@@ -117,7 +117,7 @@ func (r *environmentScannerActionResource) Create(ctx context.Context, req resou
 	case "install_grype":
 		status, err := r.client.PullImage(ctx, envID, "anchore/grype:latest", false)
 		if err != nil {
-			resp.Diagnostics.AddError("Error installing Grype scanner image", err.Error())
+			addAPIError(&resp.Diagnostics, path.Empty(), "Error installing Grype scanner image", err)
 			return
 		}
 		result["status_code"] = status
@@ -125,7 +125,7 @@ func (r *environmentScannerActionResource) Create(ctx context.Context, req resou
 	case "install_trivy":
 		status, err := r.client.PullImage(ctx, envID, "aquasec/trivy:latest", false)
 		if err != nil {
-			resp.Diagnostics.AddError("Error installing Trivy scanner image", err.Error())
+			addAPIError(&resp.Diagnostics, path.Empty(), "Error installing Trivy scanner image", err)
 			return
 		}
 		result["status_code"] = status
@@ -133,7 +133,7 @@ func (r *environmentScannerActionResource) Create(ctx context.Context, req resou
 	case "remove_grype":
 		success, status, err := r.client.RemoveScannerImage(ctx, envID, "grype")
 		if err != nil {
-			resp.Diagnostics.AddError("Error removing Grype scanner image", err.Error())
+			addAPIError(&resp.Diagnostics, path.Empty(), "Error removing Grype scanner image", err)
 			return
 		}
 		result["status_code"] = status
@@ -141,7 +141,7 @@ func (r *environmentScannerActionResource) Create(ctx context.Context, req resou
 	case "remove_trivy":
 		success, status, err := r.client.RemoveScannerImage(ctx, envID, "trivy")
 		if err != nil {
-			resp.Diagnostics.AddError("Error removing Trivy scanner image", err.Error())
+			addAPIError(&resp.Diagnostics, path.Empty(), "Error removing Trivy scanner image", err)
 			return
 		}
 		result["status_code"] = status
@@ -149,7 +149,7 @@ func (r *environmentScannerActionResource) Create(ctx context.Context, req resou
 	case "check_updates":
 		updates, status, err := r.client.CheckScannerUpdates(ctx, envID)
 		if err != nil {
-			resp.Diagnostics.AddError("Error checking scanner updates", err.Error())
+			addAPIError(&resp.Diagnostics, path.Empty(), "Error checking scanner updates", err)
 			return
 		}
 		result["status_code"] = status
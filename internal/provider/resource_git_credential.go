@@ -128,7 +128,7 @@ func (r *gitCredentialResource) Create(ctx context.Context, req resource.CreateR
 
 	created, _, err := r.client.CreateGitCredential(ctx, payload)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating Dockhand git credential", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("name"), "Error creating Dockhand git credential", err)
 		return
 	}
 
@@ -148,13 +148,13 @@ func (r *gitCredentialResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
-	cred, status, err := r.client.GetGitCredential(ctx, state.ID.ValueString())
+	cred, _, err := r.client.GetGitCredential(ctx, state.ID.ValueString())
 	if err != nil {
-		if status == 404 {
+		if IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading Dockhand git credential", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error reading Dockhand git credential", err)
 		return
 	}
 
@@ -190,7 +190,7 @@ func (r *gitCredentialResource) Update(ctx context.Context, req resource.UpdateR
 
 	updated, _, err := r.client.UpdateGitCredential(ctx, id, payload)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating Dockhand git credential", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("name"), "Error updating Dockhand git credential", err)
 		return
 	}
 
@@ -210,9 +210,9 @@ func (r *gitCredentialResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
-	status, err := r.client.DeleteGitCredential(ctx, state.ID.ValueString())
-	if err != nil && status != 404 {
-		resp.Diagnostics.AddError("Error deleting Dockhand git credential", err.Error())
+	_, err := r.client.DeleteGitCredential(ctx, state.ID.ValueString())
+	if err != nil && !IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error deleting Dockhand git credential", err)
 		return
 	}
 }
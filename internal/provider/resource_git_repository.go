@@ -178,7 +178,7 @@ func (r *gitRepositoryResource) Create(ctx context.Context, req resource.CreateR
 
 	created, _, err := r.client.CreateGitRepository(ctx, payload)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating Dockhand git repository", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("name"), "Error creating Dockhand git repository", err)
 		return
 	}
 
@@ -198,13 +198,13 @@ func (r *gitRepositoryResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
-	repo, status, err := r.client.GetGitRepository(ctx, state.ID.ValueString())
+	repo, _, err := r.client.GetGitRepository(ctx, state.ID.ValueString())
 	if err != nil {
-		if status == 404 {
+		if IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading Dockhand git repository", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error reading Dockhand git repository", err)
 		return
 	}
 
@@ -240,7 +240,7 @@ func (r *gitRepositoryResource) Update(ctx context.Context, req resource.UpdateR
 
 	updated, _, err := r.client.UpdateGitRepository(ctx, id, payload)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating Dockhand git repository", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("name"), "Error updating Dockhand git repository", err)
 		return
 	}
 
@@ -260,9 +260,9 @@ func (r *gitRepositoryResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
-	status, err := r.client.DeleteGitRepository(ctx, state.ID.ValueString())
-	if err != nil && status != 404 {
-		resp.Diagnostics.AddError("Error deleting Dockhand git repository", err.Error())
+	_, err := r.client.DeleteGitRepository(ctx, state.ID.ValueString())
+	if err != nil && !IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error deleting Dockhand git repository", err)
 		return
 	}
 }
@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -32,39 +35,46 @@ type gitStackResource struct {
 }
 
 type gitStackModel struct {
-	ID                        types.String `tfsdk:"id"`
-	Env                       types.String `tfsdk:"env"`
-	StackName                 types.String `tfsdk:"stack_name"`
-	RepositoryID              types.String `tfsdk:"repository_id"`
-	RepoName                  types.String `tfsdk:"repo_name"`
-	URL                       types.String `tfsdk:"url"`
-	Branch                    types.String `tfsdk:"branch"`
-	CredentialID              types.String `tfsdk:"credential_id"`
-	ComposePath               types.String `tfsdk:"compose_path"`
-	EnvFilePath               types.String `tfsdk:"env_file_path"`
-	AutoUpdateEnabled         types.Bool   `tfsdk:"auto_update_enabled"`
-	AutoUpdateCron            types.String `tfsdk:"auto_update_cron"`
-	WebhookEnabled            types.Bool   `tfsdk:"webhook_enabled"`
-	WebhookSecretAutoGenerate types.Bool   `tfsdk:"webhook_secret_auto_generate"`
-	WebhookSecret             types.String `tfsdk:"webhook_secret"`
-	DeployNow                 types.Bool   `tfsdk:"deploy_now"`
-	EnvVarsJSON               types.String `tfsdk:"env_vars_json"`
-	LastSync                  types.String `tfsdk:"last_sync"`
-	LastCommit                types.String `tfsdk:"last_commit"`
-	SyncStatus                types.String `tfsdk:"sync_status"`
-	SyncError                 types.String `tfsdk:"sync_error"`
-	CreatedAt                 types.String `tfsdk:"created_at"`
-	UpdatedAt                 types.String `tfsdk:"updated_at"`
-	RepositoryName            types.String `tfsdk:"repository_name"`
-	RepositoryURL             types.String `tfsdk:"repository_url"`
-	RepositoryBranch          types.String `tfsdk:"repository_branch"`
+	ID                        types.String   `tfsdk:"id"`
+	Env                       types.String   `tfsdk:"env"`
+	StackName                 types.String   `tfsdk:"stack_name"`
+	RepositoryID              types.String   `tfsdk:"repository_id"`
+	RepoName                  types.String   `tfsdk:"repo_name"`
+	URL                       types.String   `tfsdk:"url"`
+	Branch                    types.String   `tfsdk:"branch"`
+	CredentialID              types.String   `tfsdk:"credential_id"`
+	ComposePath               types.String   `tfsdk:"compose_path"`
+	EnvFilePath               types.String   `tfsdk:"env_file_path"`
+	AutoUpdateEnabled         types.Bool     `tfsdk:"auto_update_enabled"`
+	AutoUpdateCron            types.String   `tfsdk:"auto_update_cron"`
+	WebhookEnabled            types.Bool     `tfsdk:"webhook_enabled"`
+	WebhookSecretAutoGenerate types.Bool     `tfsdk:"webhook_secret_auto_generate"`
+	WebhookSecret             types.String   `tfsdk:"webhook_secret"`
+	DeployNow                 types.Bool     `tfsdk:"deploy_now"`
+	WaitForReady              types.Bool     `tfsdk:"wait_for_ready"`
+	DeployResult              types.String   `tfsdk:"deploy_result"`
+	DeployOutput              types.String   `tfsdk:"deploy_output"`
+	EnvVarsJSON               types.String   `tfsdk:"env_vars_json"`
+	LastSync                  types.String   `tfsdk:"last_sync"`
+	LastCommit                types.String   `tfsdk:"last_commit"`
+	SyncStatus                types.String   `tfsdk:"sync_status"`
+	SyncError                 types.String   `tfsdk:"sync_error"`
+	CreatedAt                 types.String   `tfsdk:"created_at"`
+	UpdatedAt                 types.String   `tfsdk:"updated_at"`
+	RepositoryName            types.String   `tfsdk:"repository_name"`
+	RepositoryURL             types.String   `tfsdk:"repository_url"`
+	RepositoryBranch          types.String   `tfsdk:"repository_branch"`
+	Timeouts                  timeouts.Value `tfsdk:"timeouts"`
 }
 
+// defaultGitStackTimeout covers clone plus compose deploy when `deploy_now` is set.
+const defaultGitStackTimeout = 10 * time.Minute
+
 func (r *gitStackResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_git_stack"
 }
 
-func (r *gitStackResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *gitStackResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages Dockhand Git-backed stacks via `/api/git/stacks` in a target environment.",
 		Attributes: map[string]schema.Attribute{
@@ -75,7 +85,7 @@ func (r *gitStackResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				},
 			},
 			"env": schema.StringAttribute{
-				MarkdownDescription: "Dockhand environment ID used as `env` query parameter.",
+				MarkdownDescription: "Dockhand environment ID or name used as `env` query parameter.",
 				Optional:            true,
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
@@ -150,7 +160,13 @@ func (r *gitStackResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				Sensitive: true,
 			},
 			"deploy_now": schema.BoolAttribute{
-				MarkdownDescription: "Whether to request immediate deployment when creating/updating this git stack.",
+				MarkdownDescription: "Whether to deploy the stack via `/deploy-stream` after creating/updating this git stack. A deploy that Dockhand reports as failed fails the apply.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"wait_for_ready": schema.BoolAttribute{
+				MarkdownDescription: "When `true` and `deploy_now` is `true`, create and update wait until every compose service of the deployed stack is running and healthy (where a healthcheck exists), or has exited with code `0` when its restart policy is `no` or `on-failure`. The wait is bounded by the `timeouts` block.",
 				Optional:            true,
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
@@ -170,6 +186,21 @@ func (r *gitStackResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 			"repository_name":   schema.StringAttribute{Computed: true},
 			"repository_url":    schema.StringAttribute{Computed: true},
 			"repository_branch": schema.StringAttribute{Computed: true},
+			"deploy_result": schema.StringAttribute{
+				MarkdownDescription: "Outcome of the last `deploy_now` deploy (`success` or `failed`). Null when no deploy ran.",
+				Computed:            true,
+			},
+			"deploy_output": schema.StringAttribute{
+				MarkdownDescription: "Log of the last `deploy_now` deploy, truncated to the last 32 KiB.",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -198,6 +229,14 @@ func (r *gitStackResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultGitStackTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	env := strings.TrimSpace(r.client.resolveEnv(plan.Env.ValueString()))
 	if env == "" {
 		resp.Diagnostics.AddError("Missing environment", "Set `env` on `dockhand_git_stack` or provider `default_env`.")
@@ -209,20 +248,30 @@ func (r *gitStackResource) Create(ctx context.Context, req resource.CreateReques
 		resp.Diagnostics.AddError("Invalid git stack configuration", err.Error())
 		return
 	}
-	if err := setGitStackPayloadEnvironment(&payload, env); err != nil {
+	envID, err := r.client.lookupEnvID(ctx, env)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, path.Root("env"), "Invalid environment", err)
+		return
+	}
+	if err := setGitStackPayloadEnvironment(&payload, envID); err != nil {
 		resp.Diagnostics.AddError("Invalid environment", err.Error())
 		return
 	}
 
+	// Deploys run through the deploy stream below so their outcome is known.
+	payload.DeployNow = false
 	created, _, err := r.client.CreateGitStack(ctx, env, payload)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating Dockhand git stack", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error creating Dockhand git stack", err)
 		return
 	}
 
 	state := mergeGitStackState(plan, modelFromGitStackResponse(created))
 	state.Env = types.StringValue(env)
+	baseline := r.captureDeployBaseline(ctx, plan, env, &resp.Diagnostics)
+	r.deployStack(ctx, plan, &state, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	r.waitForDeployedStack(ctx, plan, env, baseline, &resp.Diagnostics)
 }
 
 func (r *gitStackResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -240,7 +289,7 @@ func (r *gitStackResource) Read(ctx context.Context, req resource.ReadRequest, r
 	env := strings.TrimSpace(r.client.resolveEnv(state.Env.ValueString()))
 	item, _, err := r.client.GetGitStackByID(ctx, env, state.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Dockhand git stack", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error reading Dockhand git stack", err)
 		return
 	}
 	if item == nil {
@@ -267,6 +316,14 @@ func (r *gitStackResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultGitStackTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	env := strings.TrimSpace(r.client.resolveEnv(firstKnownString(plan.Env, state.Env)))
 	if env == "" {
 		resp.Diagnostics.AddError("Missing environment", "Set `env` on `dockhand_git_stack` or provider `default_env`.")
@@ -278,20 +335,76 @@ func (r *gitStackResource) Update(ctx context.Context, req resource.UpdateReques
 		resp.Diagnostics.AddError("Invalid git stack configuration", err.Error())
 		return
 	}
-	if err := setGitStackPayloadEnvironment(&payload, env); err != nil {
+	envID, err := r.client.lookupEnvID(ctx, env)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, path.Root("env"), "Invalid environment", err)
+		return
+	}
+	if err := setGitStackPayloadEnvironment(&payload, envID); err != nil {
 		resp.Diagnostics.AddError("Invalid environment", err.Error())
 		return
 	}
 
+	payload.DeployNow = false
 	updated, _, err := r.client.UpdateGitStack(ctx, env, state.ID.ValueString(), payload)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating Dockhand git stack", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error updating Dockhand git stack", err)
 		return
 	}
 
 	newState := mergeGitStackState(plan, modelFromGitStackResponse(updated))
 	newState.Env = types.StringValue(env)
+	baseline := r.captureDeployBaseline(ctx, plan, env, &resp.Diagnostics)
+	r.deployStack(ctx, plan, &newState, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	r.waitForDeployedStack(ctx, plan, env, baseline, &resp.Diagnostics)
+}
+
+// deployStack runs the deploy stream when `deploy_now` is set and records
+// the outcome in state. A failed deploy is saved along with its log before
+// the error is reported.
+func (r *gitStackResource) deployStack(ctx context.Context, plan gitStackModel, state *gitStackModel, diags *diag.Diagnostics) {
+	state.DeployResult = types.StringNull()
+	state.DeployOutput = types.StringNull()
+	if diags.HasError() || !plan.DeployNow.ValueBool() {
+		return
+	}
+
+	_, result, err := r.client.DeployGitStack(ctx, state.ID.ValueString())
+	if err != nil {
+		addAPIError(diags, path.Root("deploy_now"), "Error deploying Dockhand git stack", err)
+		return
+	}
+	state.DeployResult = types.StringValue(result.Result)
+	state.DeployOutput = nonEmptyStringValue(result.Log)
+	if result.Failed() {
+		diags.AddAttributeError(path.Root("deploy_now"), "Dockhand git stack deploy failed", gitDeployFailureDetail(result))
+	}
+}
+
+// captureDeployBaseline records the deployed stack before `deploy_now`
+// runs, when `wait_for_ready` will need it.
+func (r *gitStackResource) captureDeployBaseline(ctx context.Context, plan gitStackModel, env string, diags *diag.Diagnostics) stackDeployBaseline {
+	if diags.HasError() || !plan.DeployNow.ValueBool() || !plan.WaitForReady.ValueBool() {
+		return stackDeployBaseline{}
+	}
+	baseline, err := captureStackBaseline(ctx, r.client, env, strings.TrimSpace(plan.StackName.ValueString()), "")
+	if err != nil {
+		addAPIError(diags, path.Root("wait_for_ready"), "Error reading Dockhand stack before deploy", err)
+	}
+	return baseline
+}
+
+// waitForDeployedStack blocks until the stack deployed by `deploy_now` is
+// ready when `wait_for_ready` is set. State is saved before waiting so a
+// timeout leaves the git stack tracked.
+func (r *gitStackResource) waitForDeployedStack(ctx context.Context, plan gitStackModel, env string, baseline stackDeployBaseline, diags *diag.Diagnostics) {
+	if diags.HasError() || !plan.DeployNow.ValueBool() || !plan.WaitForReady.ValueBool() {
+		return
+	}
+	if err := waitForStackReady(ctx, r.client, env, strings.TrimSpace(plan.StackName.ValueString()), "", baseline); err != nil {
+		diags.AddAttributeError(path.Root("wait_for_ready"), "Dockhand git stack did not become ready", err.Error())
+	}
 }
 
 func (r *gitStackResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -306,9 +419,17 @@ func (r *gitStackResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	status, err := r.client.DeleteGitStack(ctx, state.Env.ValueString(), state.ID.ValueString())
-	if err != nil && status != 404 {
-		resp.Diagnostics.AddError("Error deleting Dockhand git stack", err.Error())
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultGitStackTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	_, err := r.client.DeleteGitStack(ctx, state.Env.ValueString(), state.ID.ValueString())
+	if err != nil && !IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error deleting Dockhand git stack", err)
 		return
 	}
 }
@@ -549,6 +670,9 @@ func modelFromGitStackResponse(in *gitStackResponse) gitStackModel {
 	out.URL = out.RepositoryURL
 	out.Branch = out.RepositoryBranch
 	out.DeployNow = types.BoolValue(false)
+	out.WaitForReady = types.BoolValue(false)
+	out.DeployResult = types.StringNull()
+	out.DeployOutput = types.StringNull()
 
 	return out
 }
@@ -599,9 +723,20 @@ func mergeGitStackState(preferred gitStackModel, remote gitStackModel) gitStackM
 	if !preferred.DeployNow.IsNull() && !preferred.DeployNow.IsUnknown() {
 		out.DeployNow = preferred.DeployNow
 	}
+	if !preferred.WaitForReady.IsNull() && !preferred.WaitForReady.IsUnknown() {
+		out.WaitForReady = preferred.WaitForReady
+	}
 	if !preferred.EnvVarsJSON.IsNull() && !preferred.EnvVarsJSON.IsUnknown() {
 		out.EnvVarsJSON = preferred.EnvVarsJSON
 	}
+	// Deploy outcome is only known from the apply that ran it.
+	if !preferred.DeployResult.IsUnknown() {
+		out.DeployResult = preferred.DeployResult
+	}
+	if !preferred.DeployOutput.IsUnknown() {
+		out.DeployOutput = preferred.DeployOutput
+	}
+	out.Timeouts = preferred.Timeouts
 
 	return out
 }
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -28,18 +30,21 @@ type gitStackDeployActionResource struct {
 }
 
 type gitStackDeployActionModel struct {
-	ID      types.String `tfsdk:"id"`
-	StackID types.String `tfsdk:"stack_id"`
-	Trigger types.String `tfsdk:"trigger"`
-	Result  types.String `tfsdk:"result"`
-	Output  types.String `tfsdk:"output"`
+	ID       types.String   `tfsdk:"id"`
+	StackID  types.String   `tfsdk:"stack_id"`
+	Trigger  types.String   `tfsdk:"trigger"`
+	Result   types.String   `tfsdk:"result"`
+	Output   types.String   `tfsdk:"output"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
+const defaultGitStackDeployTimeout = 10 * time.Minute
+
 func (r *gitStackDeployActionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_git_stack_deploy_action"
 }
 
-func (r *gitStackDeployActionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *gitStackDeployActionResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Runs a one-shot Git stack deploy request via `/api/git/stacks/{id}/deploy-stream`. Change `trigger` to run it again.",
 		Attributes: map[string]schema.Attribute{
@@ -56,8 +61,19 @@ func (r *gitStackDeployActionResource) Schema(_ context.Context, _ resource.Sche
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"result": schema.StringAttribute{Computed: true},
-			"output": schema.StringAttribute{Computed: true},
+			"result": schema.StringAttribute{
+				MarkdownDescription: "Deploy outcome decoded from the stream (`success`). A stream that ends without a completion event counts as `failed`.",
+				Computed:            true,
+			},
+			"output": schema.StringAttribute{
+				MarkdownDescription: "Deploy log from the stream, truncated to the last 32 KiB.",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
 		},
 	}
 }
@@ -86,33 +102,34 @@ func (r *gitStackDeployActionResource) Create(ctx context.Context, req resource.
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultGitStackDeployTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	stackID := strings.TrimSpace(plan.StackID.ValueString())
 	if stackID == "" {
 		resp.Diagnostics.AddError("Invalid stack ID", "`stack_id` cannot be empty.")
 		return
 	}
 
-	status, output, err := r.client.DeployGitStack(ctx, stackID)
+	_, result, err := r.client.DeployGitStack(ctx, stackID)
 	if err != nil {
-		resp.Diagnostics.AddError("Error running Dockhand git stack deploy", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("stack_id"), "Error running Dockhand git stack deploy", err)
 		return
 	}
-	if status < 200 || status > 299 {
-		msg := fmt.Sprintf("Dockhand returned status %d", status)
-		if strings.TrimSpace(output) != "" {
-			msg = fmt.Sprintf("%s: %s", msg, strings.TrimSpace(output))
-		}
-		resp.Diagnostics.AddError("Error running Dockhand git stack deploy", msg)
+	// A failed deploy is not saved, so the next apply runs it again.
+	if result.Failed() {
+		resp.Diagnostics.AddAttributeError(path.Root("stack_id"), "Dockhand git stack deploy failed", gitDeployFailureDetail(result))
 		return
 	}
 
 	plan.ID = types.StringValue(fmt.Sprintf("%s:%s", stackID, plan.Trigger.ValueString()))
-	plan.Result = types.StringValue("deploy_requested")
-	if strings.TrimSpace(output) == "" {
-		plan.Output = types.StringNull()
-	} else {
-		plan.Output = types.StringValue(strings.TrimSpace(output))
-	}
+	plan.Result = types.StringValue(result.Result)
+	plan.Output = nonEmptyStringValue(result.Log)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
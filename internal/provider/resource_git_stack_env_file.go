@@ -158,7 +158,7 @@ func (r *gitStackEnvFileResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 	if err := r.refresh(ctx, &plan); err != nil {
-		resp.Diagnostics.AddError("Error reading git stack env file", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("stack_id"), "Error reading git stack env file", err)
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -175,7 +175,7 @@ func (r *gitStackEnvFileResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 	if err := r.refresh(ctx, &state); err != nil {
-		resp.Diagnostics.AddError("Error reading git stack env file", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("stack_id"), "Error reading git stack env file", err)
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
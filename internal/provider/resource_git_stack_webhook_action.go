@@ -93,7 +93,7 @@ func (r *gitStackWebhookActionResource) Create(ctx context.Context, req resource
 
 	status, err := r.client.TriggerGitStackWebhook(ctx, stackID)
 	if err != nil {
-		resp.Diagnostics.AddError("Error triggering git stack webhook", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error triggering git stack webhook", err)
 		return
 	}
 	if status < 200 || status > 299 {
@@ -7,19 +7,25 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
 	_ resource.Resource                = (*imageResource)(nil)
 	_ resource.ResourceWithConfigure   = (*imageResource)(nil)
 	_ resource.ResourceWithImportState = (*imageResource)(nil)
+	_ resource.ResourceWithModifyPlan  = (*imageResource)(nil)
 )
 
 func NewImageResource() resource.Resource {
@@ -31,20 +37,33 @@ type imageResource struct {
 }
 
 type imageModel struct {
-	ID            types.String `tfsdk:"id"`
-	Name          types.String `tfsdk:"name"`
-	Env           types.String `tfsdk:"env"`
-	ScanAfterPull types.Bool   `tfsdk:"scan_after_pull"`
-	Tags          types.List   `tfsdk:"tags"`
-	Size          types.Int64  `tfsdk:"size"`
-	CreatedAt     types.String `tfsdk:"created_at"`
+	ID            types.String   `tfsdk:"id"`
+	Name          types.String   `tfsdk:"name"`
+	Env           types.String   `tfsdk:"env"`
+	ScanAfterPull types.Bool     `tfsdk:"scan_after_pull"`
+	RegistryID    types.Int64    `tfsdk:"registry_id"`
+	Platform      types.String   `tfsdk:"platform"`
+	PullTriggers  types.Set      `tfsdk:"pull_triggers"`
+	KeepLocally   types.Bool     `tfsdk:"keep_locally"`
+	ReplaceOnNew  types.Bool     `tfsdk:"replace_on_new_digest"`
+	RepoDigest    types.String   `tfsdk:"repo_digest"`
+	Tags          types.List     `tfsdk:"tags"`
+	Size          types.Int64    `tfsdk:"size"`
+	CreatedAt     types.String   `tfsdk:"created_at"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
 }
 
+const (
+	// Large images on slow hosts can take well over ten minutes to pull.
+	defaultImageCreateTimeout = 20 * time.Minute
+	defaultImageDeleteTimeout = 5 * time.Minute
+)
+
 func (r *imageResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_image"
 }
 
-func (r *imageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *imageResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages a Dockhand image via `/api/images` pull/delete endpoints.",
 		Attributes: map[string]schema.Attribute{
@@ -63,7 +82,7 @@ func (r *imageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"env": schema.StringAttribute{
-				MarkdownDescription: "Optional environment ID. If omitted, provider `default_env` is used.",
+				MarkdownDescription: "Optional environment ID or name. If omitted, provider `default_env` is used.",
 				Optional:            true,
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
@@ -78,6 +97,47 @@ func (r *imageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					boolRequiresReplace{},
 				},
 			},
+			"registry_id": schema.Int64Attribute{
+				MarkdownDescription: "ID of the `dockhand_registry` whose credentials are used for the pull. Use this when several registries share a hostname.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"platform": schema.StringAttribute{
+				MarkdownDescription: "Platform to pull, for example `linux/arm64`. Defaults to the platform of the Docker host.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"pull_triggers": schema.SetAttribute{
+				MarkdownDescription: "Arbitrary values that force the image to be pulled again when they change.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"keep_locally": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, destroy only removes the image from state and leaves it on the host. Defaults to `false` (the image is force-removed).",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"replace_on_new_digest": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, plan reads Dockhand's pending container updates and plans a replacement (a fresh pull) if they list a newer registry digest for `name`. Only images used by a container in the environment, and checked by Dockhand's update checks, are covered.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"repo_digest": schema.StringAttribute{
+				MarkdownDescription: "Repository digest of the pulled image (`<repository>@sha256:...`). Null when the image has no digest for the repository of `name`, for example a locally built image.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"tags": schema.ListAttribute{
 				MarkdownDescription: "Image tags reported by Dockhand.",
 				Computed:            true,
@@ -90,6 +150,13 @@ func (r *imageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Computed: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -123,14 +190,31 @@ func (r *imageResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultImageCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	env := strings.TrimSpace(plan.Env.ValueString())
 	resolvedEnv := r.client.resolveEnv(env)
 	scanAfterPull := false
 	if !plan.ScanAfterPull.IsNull() && !plan.ScanAfterPull.IsUnknown() {
 		scanAfterPull = plan.ScanAfterPull.ValueBool()
 	}
-	if _, err := r.client.PullImage(ctx, env, name, scanAfterPull); err != nil {
-		resp.Diagnostics.AddError("Error pulling image", err.Error())
+	if !plan.RegistryID.IsNull() && !plan.RegistryID.IsUnknown() && plan.RegistryID.ValueInt64() <= 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("registry_id"), "Invalid registry ID", "`registry_id` must be a positive integer.")
+		return
+	}
+	if _, err := r.client.PullImageWithOptions(ctx, env, imagePullPayload{
+		Image:         name,
+		ScanAfterPull: scanAfterPull,
+		RegistryID:    int64PtrFromInt64Value(plan.RegistryID),
+		Platform:      strings.TrimSpace(plan.Platform.ValueString()),
+	}); err != nil {
+		addAPIError(&resp.Diagnostics, path.Root("name"), "Error pulling image", err)
 		return
 	}
 
@@ -139,7 +223,7 @@ func (r *imageResource) Create(ctx context.Context, req resource.CreateRequest,
 		err   error
 	)
 	for range 5 {
-		found, err = findImageByName(ctx, r.client, env, name)
+		found, err = findImageByName(withoutResponseCache(ctx), r.client, env, name)
 		if err == nil && found != nil {
 			break
 		}
@@ -152,7 +236,7 @@ func (r *imageResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading pulled image", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("name"), "Error reading pulled image", err)
 		return
 	}
 	if found == nil {
@@ -166,6 +250,12 @@ func (r *imageResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 	state, diags := modelFromImageResponse(ctx, envVal, name, found)
 	state.ScanAfterPull = types.BoolValue(scanAfterPull)
+	state.RegistryID = plan.RegistryID
+	state.Platform = plan.Platform
+	state.PullTriggers = plan.PullTriggers
+	state.KeepLocally = plan.KeepLocally
+	state.ReplaceOnNew = plan.ReplaceOnNew
+	state.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(diags...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -185,7 +275,7 @@ func (r *imageResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	env := strings.TrimSpace(state.Env.ValueString())
 	images, _, err := r.client.ListImages(ctx, env)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Dockhand image", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error reading Dockhand image", err)
 		return
 	}
 
@@ -210,17 +300,68 @@ func (r *imageResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	newState, diags := modelFromImageResponse(ctx, state.Env, state.Name.ValueString(), found)
 	newState.ScanAfterPull = state.ScanAfterPull
+	newState.RegistryID = state.RegistryID
+	newState.Platform = state.Platform
+	newState.PullTriggers = state.PullTriggers
+	newState.KeepLocally = state.KeepLocally
+	newState.ReplaceOnNew = state.ReplaceOnNew
+	newState.Timeouts = state.Timeouts
 	resp.Diagnostics.Append(diags...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
 }
 
+// ModifyPlan plans a replacement when `replace_on_new_digest` is set and
+// Dockhand's pending updates list a newer registry digest for the image. It
+// only reads what Dockhand's update checks recorded; plan never starts a
+// registry check itself.
+func (r *imageResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan imageModel
+	var state imageModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || !plan.ReplaceOnNew.ValueBool() || plan.Name.IsUnknown() {
+		return
+	}
+
+	pending, err := r.client.PendingImageUpdates(ctx, state.Env.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("replace_on_new_digest"),
+			"Could not check registry digest",
+			fmt.Sprintf("Reading Dockhand pending container updates failed; the image is kept as is: %s", err),
+		)
+		return
+	}
+	latest := newerImageDigest(pending, plan.Name.ValueString(), state.RepoDigest.ValueString())
+	if latest == "" {
+		return
+	}
+
+	tflog.Info(ctx, "Registry has a newer digest for image; planning replacement", map[string]any{
+		"image":         plan.Name.ValueString(),
+		"latest_digest": latest,
+	})
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("repo_digest"), types.StringUnknown())...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("repo_digest"))
+}
+
 func (r *imageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// All configurable attributes require replacement.
+	// Attributes that matter to Docker require replacement; only timeouts and
+	// Terraform-side flags change in place.
+	var plan imageModel
 	var state imageModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	state.KeepLocally = plan.KeepLocally
+	state.ReplaceOnNew = plan.ReplaceOnNew
+	state.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -235,13 +376,24 @@ func (r *imageResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if state.KeepLocally.ValueBool() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultImageDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	env := strings.TrimSpace(state.Env.ValueString())
 	id := strings.TrimSpace(state.ID.ValueString())
 	if id == "" {
 		match, err := findImageByName(ctx, r.client, env, state.Name.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError("Error locating image for delete", err.Error())
+			addAPIError(&resp.Diagnostics, path.Root("id"), "Error locating image for delete", err)
 			return
 		}
 		if match == nil {
@@ -250,9 +402,9 @@ func (r *imageResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		id = match.ID
 	}
 
-	status, err := r.client.DeleteImage(ctx, env, id)
-	if err != nil && status != 404 {
-		resp.Diagnostics.AddError("Error deleting Dockhand image", err.Error())
+	_, err := r.client.DeleteImage(ctx, env, id)
+	if err != nil && !IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error deleting Dockhand image", err)
 		return
 	}
 }
@@ -269,6 +421,12 @@ func modelFromImageResponse(ctx context.Context, env types.String, name string,
 		Size: types.Int64Value(img.Size),
 	}
 
+	if digest := imageRepoDigest(name, img.RepoDigests); digest != "" {
+		out.RepoDigest = types.StringValue(digest)
+	} else {
+		out.RepoDigest = types.StringNull()
+	}
+
 	tags := append([]string(nil), img.Tags...)
 	slices.Sort(tags)
 	tagsVal, diags := types.ListValueFrom(ctx, types.StringType, tags)
@@ -314,3 +472,90 @@ func findImageMatch(images []imageResponse, name string) (*imageResponse, error)
 	}
 	return nil, nil
 }
+
+// normalizeImageReference canonicalizes Docker Hub references so that
+// `nginx`, `library/nginx:latest` and `docker.io/library/nginx:latest`
+// compare equal. Digest references are returned without the tag.
+func normalizeImageReference(ref string) string {
+	ref = strings.TrimSpace(ref)
+	repo, digest, hasDigest := strings.Cut(ref, "@")
+	tag := ""
+	if i := strings.LastIndex(repo, ":"); i > strings.LastIndex(repo, "/") {
+		repo, tag = repo[:i], repo[i+1:]
+	}
+	repo = strings.TrimPrefix(repo, "docker.io/")
+	repo = strings.TrimPrefix(repo, "index.docker.io/")
+	repo = strings.TrimPrefix(repo, "library/")
+	if hasDigest {
+		return repo + "@" + digest
+	}
+	if tag == "" {
+		tag = "latest"
+	}
+	return repo + ":" + tag
+}
+
+// imageRepository returns the repository part of a reference, normalized
+// like normalizeImageReference.
+func imageRepository(ref string) string {
+	normalized := normalizeImageReference(ref)
+	if repo, _, ok := strings.Cut(normalized, "@"); ok {
+		return repo
+	}
+	return normalized[:strings.LastIndex(normalized, ":")]
+}
+
+// imageRepoDigest picks the repo digest belonging to the repository of name.
+// Digests of other repositories sharing the image ID are never used, so it
+// returns "" when none matches.
+func imageRepoDigest(name string, digests []string) string {
+	repo := imageRepository(name)
+	for _, digest := range digests {
+		if imageRepository(digest) == repo {
+			return digest
+		}
+	}
+	return ""
+}
+
+// newerImageDigest returns the latest registry digest from an update check
+// when it differs from the digest currently pulled for name, or "" when the
+// image is up to date or was not part of the check.
+func newerImageDigest(results []containerUpdateCheckResult, name string, repoDigest string) string {
+	want := normalizeImageReference(name)
+	_, current, _ := strings.Cut(repoDigest, "@")
+	for _, result := range results {
+		if normalizeImageReference(result.ImageName) != want || result.LatestDigest == nil || *result.LatestDigest == "" {
+			continue
+		}
+		latest := *result.LatestDigest
+		if _, digest, ok := strings.Cut(latest, "@"); ok {
+			latest = digest
+		}
+		if current != "" {
+			if latest != current {
+				return latest
+			}
+			continue
+		}
+		if result.HasUpdate {
+			return latest
+		}
+	}
+	return ""
+}
+
+// imagePresent reports whether ref exactly matches a local tag or repo
+// digest. Unlike findImageMatch it does not accept other tags of the same
+// repository.
+func imagePresent(images []imageResponse, ref string) bool {
+	want := normalizeImageReference(ref)
+	for _, img := range images {
+		for _, candidate := range append(append([]string(nil), img.Tags...), img.RepoDigests...) {
+			if normalizeImageReference(candidate) == want {
+				return true
+			}
+		}
+	}
+	return false
+}
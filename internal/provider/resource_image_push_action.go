@@ -115,7 +115,7 @@ func (r *imagePushActionResource) Create(ctx context.Context, req resource.Creat
 
 	status, err := r.client.PushImage(ctx, plan.Env.ValueString(), imageID, plan.RegistryID.ValueInt64())
 	if err != nil {
-		resp.Diagnostics.AddError("Error pushing Dockhand image", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error pushing Dockhand image", err)
 		return
 	}
 	if status < 200 || status > 299 {
@@ -103,7 +103,7 @@ func (r *imageScanActionResource) Create(ctx context.Context, req resource.Creat
 
 	result, status, err := r.client.ScanImage(ctx, plan.Env.ValueString(), imageName)
 	if err != nil {
-		resp.Diagnostics.AddError("Error scanning image", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error scanning image", err)
 		return
 	}
 	if status < 200 || status > 299 {
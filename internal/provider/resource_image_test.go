@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestNormalizeImageReference(t *testing.T) {
+	cases := map[string]string{
+		"nginx":                           "nginx:latest",
+		"library/nginx:1.27":              "nginx:1.27",
+		"docker.io/library/nginx:latest":  "nginx:latest",
+		"registry.local:5000/team/app":    "registry.local:5000/team/app:latest",
+		"registry.local:5000/team/app:v2": "registry.local:5000/team/app:v2",
+		"nginx@sha256:abc":                "nginx@sha256:abc",
+		"ghcr.io/acme/api:1.0@sha256:abc": "ghcr.io/acme/api@sha256:abc",
+	}
+	for in, want := range cases {
+		if got := normalizeImageReference(in); got != want {
+			t.Errorf("normalizeImageReference(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestImageRepoDigest(t *testing.T) {
+	digests := []string{"ghcr.io/acme/nginx@sha256:other", "nginx@sha256:abc"}
+	if got := imageRepoDigest("nginx:latest", digests); got != "nginx@sha256:abc" {
+		t.Fatalf("unexpected digest %q", got)
+	}
+	if got := imageRepoDigest("busybox", digests); got != "" {
+		t.Fatalf("expected no digest of another repository, got %q", got)
+	}
+	if got := imageRepoDigest("nginx", nil); got != "" {
+		t.Fatalf("expected no digest, got %q", got)
+	}
+}
+
+func TestNewerImageDigest(t *testing.T) {
+	latest := "sha256:new"
+	current := "sha256:old"
+	results := []containerUpdateCheckResult{
+		{ImageName: "redis:7", HasUpdate: true, LatestDigest: &latest},
+		{ImageName: "docker.io/library/nginx:latest", HasUpdate: true, CurrentDigest: &current, LatestDigest: &latest},
+	}
+
+	if got := newerImageDigest(results, "nginx", "nginx@sha256:old"); got != "sha256:new" {
+		t.Fatalf("expected newer digest, got %q", got)
+	}
+	if got := newerImageDigest(results, "nginx", "nginx@sha256:new"); got != "" {
+		t.Fatalf("expected image to be up to date, got %q", got)
+	}
+	if got := newerImageDigest(results, "nginx:latest", ""); got != "sha256:new" {
+		t.Fatalf("expected hasUpdate fallback without a local digest, got %q", got)
+	}
+	if got := newerImageDigest(results, "postgres:16", "postgres@sha256:old"); got != "" {
+		t.Fatalf("expected unchecked image to be ignored, got %q", got)
+	}
+}
+
+func TestImagePresent(t *testing.T) {
+	images := []imageResponse{
+		{ID: "sha256:1", Tags: []string{"nginx:1.25"}, RepoDigests: []string{"nginx@sha256:abc"}},
+	}
+	if !imagePresent(images, "docker.io/library/nginx:1.25") {
+		t.Fatalf("expected tag match")
+	}
+	if !imagePresent(images, "nginx@sha256:abc") {
+		t.Fatalf("expected digest match")
+	}
+	if imagePresent(images, "nginx:1.27") || imagePresent(images, "nginx") {
+		t.Fatalf("expected other tags of the repository not to match")
+	}
+}
+
+func TestPendingImageUpdatesSharedUntilMutation(t *testing.T) {
+	t.Parallel()
+
+	var reads, checks atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/containers/pending-updates":
+			reads.Add(1)
+			_, _ = w.Write([]byte(`{"environmentId":1,"pendingUpdates":[{"containerId":"c1","containerName":"web","imageName":"nginx:latest","currentDigest":"sha256:old","latestDigest":"sha256:new"}]}`))
+		case r.URL.Path == "/api/containers/check-updates":
+			checks.Add(1)
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/images/pull":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "", "1", true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		pending, err := client.PendingImageUpdates(context.Background(), "1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := newerImageDigest(pending, "nginx", "nginx@sha256:old"); got != "sha256:new" {
+			t.Fatalf("expected newer digest from pending updates, got %q", got)
+		}
+	}
+	if reads.Load() != 1 || checks.Load() != 0 {
+		t.Fatalf("expected one pending-updates read and no update check, got %d reads and %d checks", reads.Load(), checks.Load())
+	}
+
+	// A change in the environment drops the shared list.
+	if _, err := client.doJSONWithStatus(context.Background(), http.MethodPost, "/api/images/pull", map[string]string{"env": "1"}, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.PendingImageUpdates(context.Background(), "1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reads.Load() != 2 {
+		t.Fatalf("expected a mutation to invalidate pending updates, got %d reads", reads.Load())
+	}
+}
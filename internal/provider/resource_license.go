@@ -119,13 +119,13 @@ func (r *licenseResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	current, status, err := r.client.GetLicense(ctx)
+	current, _, err := r.client.GetLicense(ctx)
 	if err != nil {
-		if status == 404 {
+		if IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading Dockhand license", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error reading Dockhand license", err)
 		return
 	}
 
@@ -155,9 +155,9 @@ func (r *licenseResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	status, err := r.client.DeleteLicense(ctx)
-	if err != nil && status != 404 {
-		resp.Diagnostics.AddError("Error deleting Dockhand license", err.Error())
+	_, err := r.client.DeleteLicense(ctx)
+	if err != nil && !IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error deleting Dockhand license", err)
 		return
 	}
 }
@@ -191,14 +191,14 @@ func (r *licenseResource) applyPlan(ctx context.Context, plan licenseModel) (lic
 		}
 
 		if _, _, err := r.client.SetLicense(ctx, licensePayload{Name: licenseName, Key: licenseKey}); err != nil {
-			diags.AddError("Error updating Dockhand license", err.Error())
+			addAPIError(&diags, path.Root("key"), "Error updating Dockhand license", err)
 			return licenseModel{}, diags
 		}
 	}
 
 	current, _, err := r.client.GetLicense(ctx)
 	if err != nil {
-		diags.AddError("Error reading Dockhand license", err.Error())
+		addAPIError(&diags, path.Empty(), "Error reading Dockhand license", err)
 		return licenseModel{}, diags
 	}
 
@@ -3,11 +3,14 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/netip"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -28,16 +31,30 @@ type networkResource struct {
 }
 
 type networkModel struct {
-	ID         types.String `tfsdk:"id"`
-	Name       types.String `tfsdk:"name"`
-	Driver     types.String `tfsdk:"driver"`
-	Env        types.String `tfsdk:"env"`
-	Internal   types.Bool   `tfsdk:"internal"`
-	Attachable types.Bool   `tfsdk:"attachable"`
-	Options    types.Map    `tfsdk:"options"`
-	Labels     types.Map    `tfsdk:"labels"`
-	Scope      types.String `tfsdk:"scope"`
-	CreatedAt  types.String `tfsdk:"created_at"`
+	ID         types.String      `tfsdk:"id"`
+	Name       types.String      `tfsdk:"name"`
+	Driver     types.String      `tfsdk:"driver"`
+	Env        types.String      `tfsdk:"env"`
+	Internal   types.Bool        `tfsdk:"internal"`
+	Attachable types.Bool        `tfsdk:"attachable"`
+	Options    types.Map         `tfsdk:"options"`
+	Labels     types.Map         `tfsdk:"labels"`
+	EnableIPv6 types.Bool        `tfsdk:"enable_ipv6"`
+	IPAM       *networkIPAMModel `tfsdk:"ipam"`
+	Scope      types.String      `tfsdk:"scope"`
+	CreatedAt  types.String      `tfsdk:"created_at"`
+}
+
+type networkIPAMModel struct {
+	Driver types.String             `tfsdk:"driver"`
+	Config []networkIPAMConfigModel `tfsdk:"config"`
+}
+
+type networkIPAMConfigModel struct {
+	Subnet       types.String `tfsdk:"subnet"`
+	Gateway      types.String `tfsdk:"gateway"`
+	IPRange      types.String `tfsdk:"ip_range"`
+	AuxAddresses types.Map    `tfsdk:"aux_addresses"`
 }
 
 func (r *networkResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -71,7 +88,7 @@ func (r *networkResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				},
 			},
 			"env": schema.StringAttribute{
-				MarkdownDescription: "Optional environment ID. If omitted, provider `default_env` is used.",
+				MarkdownDescription: "Optional environment ID or name. If omitted, provider `default_env` is used.",
 				Optional:            true,
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
@@ -105,6 +122,14 @@ func (r *networkResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Computed:            true,
 				ElementType:         types.StringType,
 			},
+			"enable_ipv6": schema.BoolAttribute{
+				MarkdownDescription: "Whether IPv6 is enabled on the network.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolRequiresReplace{},
+				},
+			},
 			"scope": schema.StringAttribute{
 				Computed: true,
 			},
@@ -112,6 +137,46 @@ func (r *networkResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Computed: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"ipam": schema.SingleNestedBlock{
+				MarkdownDescription: "IP address management. Refreshed from network inspect; changes recreate the network.",
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"driver": schema.StringAttribute{
+						MarkdownDescription: "IPAM driver. Docker uses `default` when unset.",
+						Optional:            true,
+					},
+				},
+				Blocks: map[string]schema.Block{
+					"config": schema.ListNestedBlock{
+						MarkdownDescription: "Address pools of the network, one per subnet.",
+						NestedObject: schema.NestedBlockObject{
+							Attributes: map[string]schema.Attribute{
+								"subnet": schema.StringAttribute{
+									MarkdownDescription: "Subnet in canonical CIDR notation (host bits zero), for example `10.42.0.0/24`.",
+									Required:            true,
+								},
+								"gateway": schema.StringAttribute{
+									MarkdownDescription: "Gateway address inside `subnet`.",
+									Optional:            true,
+								},
+								"ip_range": schema.StringAttribute{
+									MarkdownDescription: "Sub-range of `subnet`, in CIDR notation, that containers are allocated from.",
+									Optional:            true,
+								},
+								"aux_addresses": schema.MapAttribute{
+									MarkdownDescription: "Addresses reserved for other hosts, keyed by host name.",
+									Optional:            true,
+									ElementType:         types.StringType,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -164,6 +229,15 @@ func (r *networkResource) Create(ctx context.Context, req resource.CreateRequest
 			return
 		}
 	}
+	ipam, err := buildNetworkIPAMPayload(ctx, plan.IPAM)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("ipam"), "Invalid network IPAM configuration", err.Error())
+		return
+	}
+	enableIPv6 := false
+	if !plan.EnableIPv6.IsNull() && !plan.EnableIPv6.IsUnknown() {
+		enableIPv6 = plan.EnableIPv6.ValueBool()
+	}
 	env := strings.TrimSpace(plan.Env.ValueString())
 	resolvedEnv := r.client.resolveEnv(env)
 
@@ -173,9 +247,11 @@ func (r *networkResource) Create(ctx context.Context, req resource.CreateRequest
 		Internal:   internal,
 		Attachable: attachable,
 		Options:    options,
+		EnableIPv6: enableIPv6,
+		IPAM:       ipam,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating Dockhand network", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("name"), "Error creating Dockhand network", err)
 		return
 	}
 
@@ -190,6 +266,8 @@ func (r *networkResource) Create(ctx context.Context, req resource.CreateRequest
 		CreatedAt:  types.StringNull(),
 		Options:    types.MapNull(types.StringType),
 		Labels:     types.MapNull(types.StringType),
+		EnableIPv6: types.BoolValue(enableIPv6),
+		IPAM:       plan.IPAM,
 	}
 	if resolvedEnv != "" {
 		state.Env = types.StringValue(resolvedEnv)
@@ -223,7 +301,7 @@ func (r *networkResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 	networks, _, err := r.client.ListNetworks(ctx, strings.TrimSpace(state.Env.ValueString()))
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Dockhand network", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error reading Dockhand network", err)
 		return
 	}
 
@@ -239,9 +317,9 @@ func (r *networkResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	inspected, status, err := r.client.GetNetworkInspect(ctx, strings.TrimSpace(state.Env.ValueString()), state.ID.ValueString())
-	if err != nil && status != 404 {
-		resp.Diagnostics.AddError("Error reading Dockhand network inspect", err.Error())
+	inspected, _, err := r.client.GetNetworkInspect(ctx, strings.TrimSpace(state.Env.ValueString()), state.ID.ValueString())
+	if err != nil && !IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error reading Dockhand network inspect", err)
 		return
 	}
 
@@ -292,9 +370,9 @@ func (r *networkResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	status, err := r.client.DeleteNetwork(ctx, strings.TrimSpace(state.Env.ValueString()), state.ID.ValueString())
-	if err != nil && status != 404 {
-		resp.Diagnostics.AddError("Error deleting Dockhand network", err.Error())
+	_, err := r.client.DeleteNetwork(ctx, strings.TrimSpace(state.Env.ValueString()), state.ID.ValueString())
+	if err != nil && !IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error deleting Dockhand network", err)
 		return
 	}
 }
@@ -336,6 +414,105 @@ func applyNetworkInspectToState(ctx context.Context, state *networkModel, inspec
 	} else {
 		state.Labels = types.MapNull(types.StringType)
 	}
+	state.EnableIPv6 = types.BoolValue(inspected.EnableIPv6)
+	state.IPAM = refreshNetworkIPAM(state.IPAM, inspected.IPAM)
+}
+
+func buildNetworkIPAMPayload(ctx context.Context, ipam *networkIPAMModel) (*networkIPAM, error) {
+	if ipam == nil {
+		return nil, nil
+	}
+	out := &networkIPAM{Driver: strings.TrimSpace(ipam.Driver.ValueString())}
+	for i, cfg := range ipam.Config {
+		subnet := strings.TrimSpace(cfg.Subnet.ValueString())
+		prefix, err := netip.ParsePrefix(subnet)
+		if err != nil {
+			return nil, fmt.Errorf("config[%d]: `subnet` must be in CIDR notation, got %q", i, subnet)
+		}
+		// Docker stores the masked form, which would never match the
+		// configured value on read.
+		if canonical := prefix.Masked().String(); canonical != subnet {
+			return nil, fmt.Errorf("config[%d]: `subnet` %q is not in canonical form, use %q", i, subnet, canonical)
+		}
+		item := networkIPAMConfig{
+			Subnet:             subnet,
+			Gateway:            strings.TrimSpace(cfg.Gateway.ValueString()),
+			IPRange:            strings.TrimSpace(cfg.IPRange.ValueString()),
+			AuxiliaryAddresses: flattenStringMap(ctx, cfg.AuxAddresses),
+		}
+		if item.Gateway != "" {
+			gateway, err := netip.ParseAddr(item.Gateway)
+			if err != nil || !prefix.Contains(gateway) {
+				return nil, fmt.Errorf("config[%d]: `gateway` %q must be an address inside %s", i, item.Gateway, subnet)
+			}
+		}
+		if item.IPRange != "" {
+			ipRange, err := netip.ParsePrefix(item.IPRange)
+			if err != nil || !prefix.Contains(ipRange.Addr()) || ipRange.Bits() < prefix.Bits() {
+				return nil, fmt.Errorf("config[%d]: `ip_range` %q must be a CIDR range inside %s", i, item.IPRange, subnet)
+			}
+		}
+		out.Config = append(out.Config, item)
+	}
+	return out, nil
+}
+
+// refreshNetworkIPAM reconciles the configured IPAM block with inspect.
+// Pools are matched by their masked subnet prefix and kept in configured
+// order. Configured pools
+// that are gone are dropped so the change shows up, while pools Docker added
+// on its own (such as the automatic IPv6 pool of `enable_ipv6`) are ignored.
+// Attributes left unset in configuration stay null so Docker-assigned
+// gateways do not show up as drift. Networks without an `ipam` block keep it
+// null.
+func refreshNetworkIPAM(configured *networkIPAMModel, inspected *networkIPAM) *networkIPAMModel {
+	if configured == nil || inspected == nil {
+		return configured
+	}
+
+	out := &networkIPAMModel{Driver: configured.Driver}
+	if configured.Config != nil {
+		out.Config = []networkIPAMConfigModel{}
+	}
+	if !configured.Driver.IsNull() && inspected.Driver != "" {
+		out.Driver = types.StringValue(inspected.Driver)
+	}
+
+	bySubnet := map[string]networkIPAMConfig{}
+	for _, actual := range inspected.Config {
+		bySubnet[subnetKey(actual.Subnet)] = actual
+	}
+	for _, cfg := range configured.Config {
+		actual, ok := bySubnet[subnetKey(cfg.Subnet.ValueString())]
+		if !ok {
+			continue
+		}
+		if !cfg.Gateway.IsNull() {
+			cfg.Gateway = types.StringValue(actual.Gateway)
+		}
+		if !cfg.IPRange.IsNull() {
+			cfg.IPRange = types.StringValue(actual.IPRange)
+		}
+		if !cfg.AuxAddresses.IsNull() {
+			cfg.AuxAddresses = stringMapValueOrNull(actual.AuxiliaryAddresses)
+			if cfg.AuxAddresses.IsNull() {
+				cfg.AuxAddresses = types.MapValueMust(types.StringType, map[string]attr.Value{})
+			}
+		}
+		out.Config = append(out.Config, cfg)
+	}
+	return out
+}
+
+// subnetKey returns the masked form of a CIDR subnet, or the trimmed value
+// when it does not parse.
+func subnetKey(subnet string) string {
+	subnet = strings.TrimSpace(subnet)
+	prefix, err := netip.ParsePrefix(subnet)
+	if err != nil {
+		return subnet
+	}
+	return prefix.Masked().String()
 }
 
 type boolRequiresReplace struct{}
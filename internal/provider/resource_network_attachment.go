@@ -0,0 +1,317 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = (*networkAttachmentResource)(nil)
+	_ resource.ResourceWithConfigure   = (*networkAttachmentResource)(nil)
+	_ resource.ResourceWithImportState = (*networkAttachmentResource)(nil)
+)
+
+func NewNetworkAttachmentResource() resource.Resource {
+	return &networkAttachmentResource{}
+}
+
+type networkAttachmentResource struct {
+	client *Client
+}
+
+type networkAttachmentModel struct {
+	ID          types.String `tfsdk:"id"`
+	Env         types.String `tfsdk:"env"`
+	NetworkID   types.String `tfsdk:"network_id"`
+	ContainerID types.String `tfsdk:"container_id"`
+	Aliases     types.Set    `tfsdk:"aliases"`
+	IPv4Address types.String `tfsdk:"ipv4_address"`
+	IPv6Address types.String `tfsdk:"ipv6_address"`
+	EndpointID  types.String `tfsdk:"endpoint_id"`
+}
+
+func (r *networkAttachmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_network_attachment"
+}
+
+func (r *networkAttachmentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Keeps a container attached to a Dockhand network. Unlike `dockhand_network_connection_action`, the attachment is read back from network inspect, so a manual disconnect is detected and repaired on the next apply.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Attachment ID in the format `<env>:<network_id>:<container_id>`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"env": schema.StringAttribute{
+				MarkdownDescription: "Optional environment ID or name. If omitted, provider `default_env` is used.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"network_id": schema.StringAttribute{
+				MarkdownDescription: "Network ID or name.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"container_id": schema.StringAttribute{
+				MarkdownDescription: "Container ID or name.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"aliases": schema.SetAttribute{
+				MarkdownDescription: "DNS aliases of the container on this network.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"ipv4_address": schema.StringAttribute{
+				MarkdownDescription: "Static IPv4 address on this network. When unset, the address Docker assigned is reported.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ipv6_address": schema.StringAttribute{
+				MarkdownDescription: "Static IPv6 address on this network. When unset, the address Docker assigned is reported.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"endpoint_id": schema.StringAttribute{
+				MarkdownDescription: "Docker endpoint ID of the attachment.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *networkAttachmentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *Client, got: %T", req.ProviderData))
+		return
+	}
+	r.client = client
+}
+
+func (r *networkAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Unconfigured client", "The provider client was not configured.")
+		return
+	}
+
+	var plan networkAttachmentModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	env := strings.TrimSpace(plan.Env.ValueString())
+	networkID := strings.TrimSpace(plan.NetworkID.ValueString())
+	containerID := strings.TrimSpace(plan.ContainerID.ValueString())
+	if networkID == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("network_id"), "Invalid network ID", "`network_id` cannot be empty.")
+		return
+	}
+	if containerID == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("container_id"), "Invalid container ID", "`container_id` cannot be empty.")
+		return
+	}
+
+	payload := buildContainerNetworkPayload(ctx, containerID, containerNetworkModel{
+		Network:     plan.NetworkID,
+		Aliases:     plan.Aliases,
+		IPv4Address: plan.IPv4Address,
+		IPv6Address: plan.IPv6Address,
+	})
+	if _, err := r.client.ConnectNetworkEndpoint(ctx, env, networkID, payload); err != nil {
+		addAPIError(&resp.Diagnostics, path.Root("network_id"), "Error attaching container to Dockhand network", err)
+		return
+	}
+
+	plan.ID = types.StringValue(formatNetworkAttachmentID(r.client.resolveEnv(env), networkID, containerID))
+	plan.EndpointID = types.StringNull()
+	inspected, _, err := r.client.GetNetworkInspect(ctx, env, networkID)
+	if err == nil && inspected != nil {
+		if member, ok := findNetworkMember(inspected.Containers, containerID); ok {
+			applyNetworkMemberToState(&plan, member)
+		}
+	}
+	if plan.IPv4Address.IsUnknown() {
+		plan.IPv4Address = types.StringNull()
+	}
+	if plan.IPv6Address.IsUnknown() {
+		plan.IPv6Address = types.StringNull()
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *networkAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Unconfigured client", "The provider client was not configured.")
+		return
+	}
+
+	var state networkAttachmentModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	env := strings.TrimSpace(state.Env.ValueString())
+	inspected, _, err := r.client.GetNetworkInspect(ctx, env, state.NetworkID.ValueString())
+	if IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error reading Dockhand network attachment", err)
+		return
+	}
+
+	// A container that left the network (or was removed) drops the
+	// attachment from state so the next apply reconnects it.
+	member, ok := findNetworkMember(inspected.Containers, state.ContainerID.ValueString())
+	if !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	applyNetworkMemberToState(&state, member)
+
+	// Network inspect does not list aliases, so they come from container
+	// inspect. Only configured aliases are kept.
+	if !state.Aliases.IsNull() {
+		container, _, err := r.client.InspectContainer(ctx, env, state.ContainerID.ValueString())
+		if err != nil {
+			addAPIError(&resp.Diagnostics, path.Root("aliases"), "Error reading Dockhand network attachment aliases", err)
+			return
+		}
+		endpoint, ok := findContainerEndpoint(container.NetworkSettings.Networks, state.NetworkID.ValueString())
+		if !ok {
+			endpoint, ok = findContainerEndpoint(container.NetworkSettings.Networks, inspected.ID)
+		}
+		if ok {
+			state.Aliases = keepConfiguredAliases(ctx, state.Aliases, endpoint.Aliases)
+		}
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *networkAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// All configurable attributes require replacement.
+	var plan networkAttachmentModel
+	var state networkAttachmentModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ID = state.ID
+	plan.EndpointID = state.EndpointID
+	if plan.IPv4Address.IsUnknown() {
+		plan.IPv4Address = state.IPv4Address
+	}
+	if plan.IPv6Address.IsUnknown() {
+		plan.IPv6Address = state.IPv6Address
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *networkAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Unconfigured client", "The provider client was not configured.")
+		return
+	}
+
+	var state networkAttachmentModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.DisconnectNetwork(ctx, strings.TrimSpace(state.Env.ValueString()), state.NetworkID.ValueString(), state.ContainerID.ValueString())
+	if err != nil && !IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error detaching container from Dockhand network", err)
+		return
+	}
+}
+
+func (r *networkAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(strings.TrimSpace(req.ID), ":")
+	if len(parts) != 3 || strings.TrimSpace(parts[1]) == "" || strings.TrimSpace(parts[2]) == "" {
+		resp.Diagnostics.AddError("Invalid import ID", "Expected `<env>:<network_id>:<container_id>` (env may be empty to use provider `default_env`).")
+		return
+	}
+
+	env := strings.TrimSpace(parts[0])
+	networkID := strings.TrimSpace(parts[1])
+	containerID := strings.TrimSpace(parts[2])
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), formatNetworkAttachmentID(env, networkID, containerID))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("network_id"), networkID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("container_id"), containerID)...)
+	if env != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("env"), env)...)
+	}
+}
+
+func formatNetworkAttachmentID(env string, networkID string, containerID string) string {
+	return env + ":" + networkID + ":" + containerID
+}
+
+// applyNetworkMemberToState copies the endpoint ID and addresses reported by
+// network inspect. Docker reports addresses in CIDR form; the prefix length
+// is dropped to match the configured value.
+func applyNetworkMemberToState(state *networkAttachmentModel, member networkInspectContainer) {
+	state.EndpointID = nonEmptyStringValue(member.EndpointID)
+	state.IPv4Address = nonEmptyStringValue(stripAddressPrefix(member.IPv4Address))
+	state.IPv6Address = nonEmptyStringValue(stripAddressPrefix(member.IPv6Address))
+}
+
+func stripAddressPrefix(address string) string {
+	address, _, _ = strings.Cut(strings.TrimSpace(address), "/")
+	return address
+}
+
+// findNetworkMember looks up a container in the network inspect membership
+// by full ID, short ID prefix or container name.
+func findNetworkMember(members map[string]networkInspectContainer, container string) (networkInspectContainer, bool) {
+	container = strings.TrimSpace(container)
+	if container == "" {
+		return networkInspectContainer{}, false
+	}
+	if member, ok := members[container]; ok {
+		return member, true
+	}
+	for id, member := range members {
+		if (len(container) >= 12 && strings.HasPrefix(id, container)) || strings.TrimPrefix(member.Name, "/") == strings.TrimPrefix(container, "/") {
+			return member, true
+		}
+	}
+	return networkInspectContainer{}, false
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestFindNetworkMember(t *testing.T) {
+	members := map[string]networkInspectContainer{
+		"3f2a1b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708": {
+			Name:       "web",
+			EndpointID: "ep-1",
+		},
+	}
+
+	for _, ref := range []string{
+		"3f2a1b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708",
+		"3f2a1b4c5d6e",
+		"web",
+		"/web",
+	} {
+		member, ok := findNetworkMember(members, ref)
+		if !ok || member.EndpointID != "ep-1" {
+			t.Fatalf("expected %q to match, got %#v, %v", ref, member, ok)
+		}
+	}
+
+	for _, ref := range []string{"", "3f2a", "db"} {
+		if _, ok := findNetworkMember(members, ref); ok {
+			t.Fatalf("expected %q not to match", ref)
+		}
+	}
+}
+
+func TestNetworkAttachmentImportAndRead(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/networks/backend/inspect":
+			_, _ = w.Write([]byte(`{"id":"ffff","name":"backend","containers":{"3f2a1b4c5d6e":{"name":"web","endpointId":"ep-1","ipv4Address":"10.1.0.10/24","ipv6Address":""}}}`))
+		case "/api/containers/web":
+			_, _ = w.Write([]byte(`{"Id":"3f2a1b4c5d6e","NetworkSettings":{"Networks":{"backend":{"NetworkID":"ffff","Aliases":["api","3f2a1b4c5d6e"]}}}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "", "1", true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	r := &networkAttachmentResource{client: client}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	empty := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+
+	importResp := resource.ImportStateResponse{State: empty}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "1:backend:web"}, &importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected import diagnostics: %v", importResp.Diagnostics)
+	}
+
+	readResp := resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var imported networkAttachmentModel
+	readResp.Diagnostics.Append(readResp.State.Get(ctx, &imported)...)
+	if imported.EndpointID.ValueString() != "ep-1" {
+		t.Fatalf("expected endpoint ID, got %#v", imported.EndpointID)
+	}
+	if imported.IPv4Address.ValueString() != "10.1.0.10" {
+		t.Fatalf("expected IPv4 address without prefix, got %#v", imported.IPv4Address)
+	}
+	if !imported.IPv6Address.IsNull() {
+		t.Fatalf("expected no IPv6 address, got %#v", imported.IPv6Address)
+	}
+	if !imported.Aliases.IsNull() {
+		t.Fatalf("expected unconfigured aliases to stay null, got %#v", imported.Aliases)
+	}
+
+	// With aliases configured, only those still present are kept.
+	imported.Aliases = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("api"), types.StringValue("old")})
+	configured := tfsdk.State{Schema: schemaResp.Schema}
+	readResp.Diagnostics.Append(configured.Set(ctx, &imported)...)
+	readResp = resource.ReadResponse{State: configured}
+	r.Read(ctx, resource.ReadRequest{State: configured}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var refreshed networkAttachmentModel
+	readResp.Diagnostics.Append(readResp.State.Get(ctx, &refreshed)...)
+	if got := flattenStringSet(ctx, refreshed.Aliases); len(got) != 1 || got[0] != "api" {
+		t.Fatalf("expected only configured aliases present on the endpoint, got %#v", got)
+	}
+}
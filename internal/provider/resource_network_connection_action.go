@@ -134,7 +134,7 @@ func (r *networkConnectionActionResource) Create(ctx context.Context, req resour
 		return
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Error running Dockhand network connection action", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error running Dockhand network connection action", err)
 		return
 	}
 	if status < 200 || status > 299 {
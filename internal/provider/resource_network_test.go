@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestBuildNetworkIPAMPayload(t *testing.T) {
+	ctx := context.Background()
+
+	payload, err := buildNetworkIPAMPayload(ctx, &networkIPAMModel{
+		Driver: types.StringNull(),
+		Config: []networkIPAMConfigModel{
+			{
+				Subnet:       types.StringValue("10.42.0.0/24"),
+				Gateway:      types.StringValue("10.42.0.1"),
+				IPRange:      types.StringValue("10.42.0.128/25"),
+				AuxAddresses: types.MapNull(types.StringType),
+			},
+			{
+				Subnet:       types.StringValue("fd00:42::/64"),
+				Gateway:      types.StringNull(),
+				IPRange:      types.StringNull(),
+				AuxAddresses: types.MapNull(types.StringType),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(payload.Config) != 2 || payload.Config[0].Gateway != "10.42.0.1" || payload.Config[1].Subnet != "fd00:42::/64" {
+		t.Fatalf("unexpected payload: %#v", payload)
+	}
+
+	invalid := []networkIPAMConfigModel{
+		{Subnet: types.StringValue("10.42.0.0")},
+		{Subnet: types.StringValue("10.42.0.0/24"), Gateway: types.StringValue("10.43.0.1")},
+		{Subnet: types.StringValue("10.42.0.0/24"), IPRange: types.StringValue("10.42.0.0/16")},
+		{Subnet: types.StringValue("10.42.0.5/24")},
+		{Subnet: types.StringValue("FD00:42::/64")},
+	}
+	for _, cfg := range invalid {
+		if _, err := buildNetworkIPAMPayload(ctx, &networkIPAMModel{Config: []networkIPAMConfigModel{cfg}}); err == nil {
+			t.Fatalf("expected error for %#v", cfg)
+		}
+	}
+}
+
+func TestRefreshNetworkIPAM(t *testing.T) {
+	configured := &networkIPAMModel{
+		Driver: types.StringNull(),
+		Config: []networkIPAMConfigModel{
+			{
+				Subnet:       types.StringValue("10.42.0.0/24"),
+				Gateway:      types.StringNull(),
+				IPRange:      types.StringValue("10.42.0.128/25"),
+				AuxAddresses: types.MapNull(types.StringType),
+			},
+			{
+				Subnet:       types.StringValue("10.50.0.0/24"),
+				Gateway:      types.StringNull(),
+				IPRange:      types.StringNull(),
+				AuxAddresses: types.MapNull(types.StringType),
+			},
+		},
+	}
+	inspected := &networkIPAM{
+		Driver: "default",
+		Config: []networkIPAMConfig{
+			{Subnet: "fd00:dead:beef::/64", Gateway: "fd00:dead:beef::1"},
+			{Subnet: "10.60.0.0/24", Gateway: "10.60.0.1"},
+			{Subnet: "10.42.0.0/24", Gateway: "10.42.0.1", IPRange: "10.42.0.0/25"},
+		},
+	}
+
+	refreshed := refreshNetworkIPAM(configured, inspected)
+	if !refreshed.Driver.IsNull() {
+		t.Fatalf("expected unset driver to stay null, got %s", refreshed.Driver)
+	}
+	// Unmanaged pools, including the automatic IPv6 pool, are ignored and
+	// the missing 10.50.0.0/24 pool is dropped.
+	if len(refreshed.Config) != 1 {
+		t.Fatalf("expected only the configured pool still present, got %#v", refreshed.Config)
+	}
+	if refreshed.Config[0].Subnet.ValueString() != "10.42.0.0/24" || !refreshed.Config[0].Gateway.IsNull() || refreshed.Config[0].IPRange.ValueString() != "10.42.0.0/25" {
+		t.Fatalf("unexpected pool: %#v", refreshed.Config[0])
+	}
+
+	// Docker's pool order does not reorder configured pools.
+	inspected.Config = append(inspected.Config, networkIPAMConfig{Subnet: "10.50.0.0/24", Gateway: "10.50.0.1"})
+	inspected.Config[0], inspected.Config[3] = inspected.Config[3], inspected.Config[0]
+	refreshed = refreshNetworkIPAM(configured, inspected)
+	if len(refreshed.Config) != 2 || refreshed.Config[0].Subnet.ValueString() != "10.42.0.0/24" || refreshed.Config[1].Subnet.ValueString() != "10.50.0.0/24" {
+		t.Fatalf("expected configured pool order, got %#v", refreshed.Config)
+	}
+
+	// Subnets are compared as prefixes, not strings.
+	inspected.Config = []networkIPAMConfig{{Subnet: "fd00:42:0::/64"}}
+	refreshed = refreshNetworkIPAM(&networkIPAMModel{
+		Driver: types.StringNull(),
+		Config: []networkIPAMConfigModel{{Subnet: types.StringValue("fd00:42::/64"), Gateway: types.StringNull(), IPRange: types.StringNull(), AuxAddresses: types.MapNull(types.StringType)}},
+	}, inspected)
+	if len(refreshed.Config) != 1 || refreshed.Config[0].Subnet.ValueString() != "fd00:42::/64" {
+		t.Fatalf("expected equivalent prefix to match, got %#v", refreshed.Config)
+	}
+
+	if refreshNetworkIPAM(nil, inspected) != nil {
+		t.Fatalf("expected networks without ipam to stay null")
+	}
+}
@@ -189,7 +189,7 @@ func (r *notificationResource) Create(ctx context.Context, req resource.CreateRe
 
 	created, _, err := r.client.CreateNotification(ctx, payload)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating Dockhand notification", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("name"), "Error creating Dockhand notification", err)
 		return
 	}
 
@@ -209,13 +209,13 @@ func (r *notificationResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
-	n, status, err := r.client.GetNotification(ctx, state.ID.ValueString())
+	n, _, err := r.client.GetNotification(ctx, state.ID.ValueString())
 	if err != nil {
-		if status == 404 {
+		if IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading Dockhand notification", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error reading Dockhand notification", err)
 		return
 	}
 
@@ -251,7 +251,7 @@ func (r *notificationResource) Update(ctx context.Context, req resource.UpdateRe
 
 	updated, _, err := r.client.UpdateNotification(ctx, id, payload)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating Dockhand notification", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("name"), "Error updating Dockhand notification", err)
 		return
 	}
 
@@ -271,9 +271,9 @@ func (r *notificationResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
-	status, err := r.client.DeleteNotification(ctx, state.ID.ValueString())
-	if err != nil && status != 404 {
-		resp.Diagnostics.AddError("Error deleting Dockhand notification", err.Error())
+	_, err := r.client.DeleteNotification(ctx, state.ID.ValueString())
+	if err != nil && !IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error deleting Dockhand notification", err)
 		return
 	}
 }
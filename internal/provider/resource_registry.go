@@ -122,7 +122,7 @@ func (r *registryResource) Create(ctx context.Context, req resource.CreateReques
 
 	created, _, err := r.client.CreateRegistry(ctx, payload)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating Dockhand registry", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("name"), "Error creating Dockhand registry", err)
 		return
 	}
 
@@ -142,13 +142,13 @@ func (r *registryResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	reg, status, err := r.client.GetRegistry(ctx, state.ID.ValueString())
+	reg, _, err := r.client.GetRegistry(ctx, state.ID.ValueString())
 	if err != nil {
-		if status == 404 {
+		if IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading Dockhand registry", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error reading Dockhand registry", err)
 		return
 	}
 
@@ -184,7 +184,7 @@ func (r *registryResource) Update(ctx context.Context, req resource.UpdateReques
 
 	updated, _, err := r.client.UpdateRegistry(ctx, id, payload)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating Dockhand registry", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("name"), "Error updating Dockhand registry", err)
 		return
 	}
 
@@ -204,9 +204,9 @@ func (r *registryResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	status, err := r.client.DeleteRegistry(ctx, state.ID.ValueString())
-	if err != nil && status != 404 {
-		resp.Diagnostics.AddError("Error deleting Dockhand registry", err.Error())
+	_, err := r.client.DeleteRegistry(ctx, state.ID.ValueString())
+	if err != nil && !IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error deleting Dockhand registry", err)
 		return
 	}
 }
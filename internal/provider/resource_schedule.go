@@ -112,7 +112,7 @@ func (r *scheduleResource) Create(ctx context.Context, req resource.CreateReques
 
 	sched, err := r.resolveSchedule(ctx, plan.Type.ValueString(), plan.ScheduleID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Dockhand schedule", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("schedule_id"), "Error reading Dockhand schedule", err)
 		return
 	}
 	if sched == nil {
@@ -123,12 +123,12 @@ func (r *scheduleResource) Create(ctx context.Context, req resource.CreateReques
 	desired := plan.Enabled.ValueBool()
 	if sched.Enabled != desired {
 		if _, err := r.client.ToggleSchedule(ctx, sched.Type, strconv.FormatInt(sched.ID, 10), sched.IsSystem); err != nil {
-			resp.Diagnostics.AddError("Error toggling Dockhand schedule", err.Error())
+			addAPIError(&resp.Diagnostics, path.Root("name"), "Error toggling Dockhand schedule", err)
 			return
 		}
 		sched, err = r.resolveSchedule(ctx, plan.Type.ValueString(), plan.ScheduleID.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError("Error reading Dockhand schedule", err.Error())
+			addAPIError(&resp.Diagnostics, path.Root("name"), "Error reading Dockhand schedule", err)
 			return
 		}
 		if sched == nil {
@@ -155,7 +155,7 @@ func (r *scheduleResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	sched, err := r.resolveSchedule(ctx, state.Type.ValueString(), state.ScheduleID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Dockhand schedule", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("schedule_id"), "Error reading Dockhand schedule", err)
 		return
 	}
 	if sched == nil {
@@ -183,7 +183,7 @@ func (r *scheduleResource) Update(ctx context.Context, req resource.UpdateReques
 
 	sched, err := r.resolveSchedule(ctx, plan.Type.ValueString(), plan.ScheduleID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Dockhand schedule", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("schedule_id"), "Error reading Dockhand schedule", err)
 		return
 	}
 	if sched == nil {
@@ -194,12 +194,12 @@ func (r *scheduleResource) Update(ctx context.Context, req resource.UpdateReques
 	desired := plan.Enabled.ValueBool()
 	if sched.Enabled != desired {
 		if _, err := r.client.ToggleSchedule(ctx, sched.Type, strconv.FormatInt(sched.ID, 10), sched.IsSystem); err != nil {
-			resp.Diagnostics.AddError("Error toggling Dockhand schedule", err.Error())
+			addAPIError(&resp.Diagnostics, path.Root("name"), "Error toggling Dockhand schedule", err)
 			return
 		}
 		sched, err = r.resolveSchedule(ctx, plan.Type.ValueString(), plan.ScheduleID.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError("Error reading Dockhand schedule", err.Error())
+			addAPIError(&resp.Diagnostics, path.Root("name"), "Error reading Dockhand schedule", err)
 			return
 		}
 		if sched == nil {
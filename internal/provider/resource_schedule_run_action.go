@@ -92,7 +92,7 @@ func (r *scheduleRunActionResource) Create(ctx context.Context, req resource.Cre
 
 	status, err := r.client.RunSchedule(ctx, scheduleType, scheduleID)
 	if err != nil {
-		resp.Diagnostics.AddError("Error running Dockhand schedule", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error running Dockhand schedule", err)
 		return
 	}
 	if status < 200 || status > 299 {
@@ -246,7 +246,7 @@ func (r *generalSettingsResource) applyPlan(ctx context.Context, plan generalSet
 
 	current, _, err := r.client.GetGeneralSettings(ctx)
 	if err != nil {
-		diags.AddError("Error reading Dockhand general settings", err.Error())
+		addAPIError(&diags, path.Empty(), "Error reading Dockhand general settings", err)
 		return generalSettingsModel{}, diags
 	}
 
@@ -258,7 +258,7 @@ func (r *generalSettingsResource) applyPlan(ctx context.Context, plan generalSet
 
 	updated, _, err := r.client.UpdateGeneralSettings(ctx, payload)
 	if err != nil {
-		diags.AddError("Error updating Dockhand general settings", err.Error())
+		addAPIError(&diags, path.Empty(), "Error updating Dockhand general settings", err)
 		return generalSettingsModel{}, diags
 	}
 
@@ -273,13 +273,13 @@ func (r *generalSettingsResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	current, status, err := r.client.GetGeneralSettings(ctx)
+	current, _, err := r.client.GetGeneralSettings(ctx)
 	if err != nil {
-		if status == 404 {
+		if IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading Dockhand general settings", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error reading Dockhand general settings", err)
 		return
 	}
 
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -16,9 +18,10 @@ import (
 )
 
 var (
-	_ resource.Resource                = (*stackResource)(nil)
-	_ resource.ResourceWithConfigure   = (*stackResource)(nil)
-	_ resource.ResourceWithImportState = (*stackResource)(nil)
+	_ resource.Resource                   = (*stackResource)(nil)
+	_ resource.ResourceWithConfigure      = (*stackResource)(nil)
+	_ resource.ResourceWithImportState    = (*stackResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*stackResource)(nil)
 )
 
 func NewStackResource() resource.Resource {
@@ -30,21 +33,25 @@ type stackResource struct {
 }
 
 type stackResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	Name           types.String `tfsdk:"name"`
-	Env            types.String `tfsdk:"env"`
-	Compose        types.String `tfsdk:"compose"`
-	Enabled        types.Bool   `tfsdk:"enabled"`
-	Status         types.String `tfsdk:"status"`
-	ContainerIDs   types.List   `tfsdk:"container_ids"`
-	ContainerCount types.Int64  `tfsdk:"container_count"`
+	ID             types.String   `tfsdk:"id"`
+	Name           types.String   `tfsdk:"name"`
+	Env            types.String   `tfsdk:"env"`
+	Compose        composeValue   `tfsdk:"compose"`
+	Enabled        types.Bool     `tfsdk:"enabled"`
+	Status         types.String   `tfsdk:"status"`
+	ContainerIDs   types.List     `tfsdk:"container_ids"`
+	ContainerCount types.Int64    `tfsdk:"container_count"`
+	WaitForReady   types.Bool     `tfsdk:"wait_for_ready"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
 }
 
+const defaultStackTimeout = 10 * time.Minute
+
 func (r *stackResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_stack"
 }
 
-func (r *stackResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *stackResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages a Dockhand stack using the documented /api/stacks endpoints.",
 		Attributes: map[string]schema.Attribute{
@@ -60,18 +67,16 @@ func (r *stackResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"env": schema.StringAttribute{
-				MarkdownDescription: "Dockhand environment ID sent as the `env` query parameter.",
+				MarkdownDescription: "Dockhand environment ID or name sent as the `env` query parameter.",
 				Optional:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"compose": schema.StringAttribute{
-				MarkdownDescription: "Stack Docker Compose manifest content.",
+				MarkdownDescription: "Stack Docker Compose manifest content. Validated locally before apply. Changes are written in place and the stack is redeployed when `enabled` is `true`; formatting-only changes (whitespace, comments, key order) are applied to state without touching the stack, and an equivalent manifest returned by Dockhand is not drift.",
+				CustomType:          composeType{},
 				Required:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"enabled": schema.BoolAttribute{
 				MarkdownDescription: "Whether the stack should be started after create and kept running.",
@@ -79,6 +84,12 @@ func (r *stackResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Computed:            true,
 				Default:             booldefault.StaticBool(true),
 			},
+			"wait_for_ready": schema.BoolAttribute{
+				MarkdownDescription: "When `true` and the stack is enabled, create and update wait until every compose service is running and healthy (where a healthcheck exists), or has exited with code `0` when its restart policy is `no` or `on-failure`. The wait is bounded by the `timeouts` block.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 			"status": schema.StringAttribute{
 				MarkdownDescription: "Current stack runtime status as reported by Dockhand.",
 				Computed:            true,
@@ -93,6 +104,13 @@ func (r *stackResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Computed:            true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -113,6 +131,18 @@ func (r *stackResource) Configure(_ context.Context, req resource.ConfigureReque
 	r.client = client
 }
 
+func (r *stackResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var compose composeValue
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("compose"), &compose)...)
+	if resp.Diagnostics.HasError() || compose.IsUnknown() || compose.IsNull() {
+		return
+	}
+
+	for _, problem := range validateComposeManifest(compose.ValueString()) {
+		resp.Diagnostics.AddAttributeError(path.Root("compose"), "Invalid compose manifest", problem)
+	}
+}
+
 func (r *stackResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	if r.client == nil {
 		resp.Diagnostics.AddError("Unconfigured client", "The provider client was not configured.")
@@ -125,6 +155,14 @@ func (r *stackResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultStackTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	env := plan.Env.ValueString()
 	name := plan.Name.ValueString()
 
@@ -132,7 +170,7 @@ func (r *stackResource) Create(ctx context.Context, req resource.CreateRequest,
 		Name:    name,
 		Compose: plan.Compose.ValueString(),
 	}); err != nil {
-		resp.Diagnostics.AddError("Error creating Dockhand stack", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("name"), "Error creating Dockhand stack", err)
 		return
 	}
 
@@ -140,23 +178,35 @@ func (r *stackResource) Create(ctx context.Context, req resource.CreateRequest,
 	// explicitly stop it after creation.
 	if !plan.Enabled.ValueBool() {
 		if err := r.client.StopStack(ctx, env, name); err != nil {
-			resp.Diagnostics.AddError("Error stopping Dockhand stack after create", err.Error())
+			addAPIError(&resp.Diagnostics, path.Root("name"), "Error stopping Dockhand stack after create", err)
 			return
 		}
 	}
 
-	stack, found, err := r.client.GetStackByName(ctx, env, name)
+	var readyErr error
+	if plan.Enabled.ValueBool() && plan.WaitForReady.ValueBool() {
+		readyErr = waitForStackReady(ctx, r.client, env, name, plan.Compose.ValueString(), stackDeployBaseline{})
+	}
+
+	stack, found, err := r.client.GetStackByName(context.WithoutCancel(ctx), env, name)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Dockhand stack after create", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("name"), "Error reading Dockhand stack after create", err)
 		return
 	}
 	if found {
 		plan.Status = types.StringValue(stack.Status)
 		plan.ContainerIDs = stringSliceToListValue(stack.Containers)
 		plan.ContainerCount = types.Int64Value(int64(len(stack.Containers)))
+	} else {
+		plan.Status = types.StringNull()
+		plan.ContainerIDs = stringSliceToListValue(nil)
+		plan.ContainerCount = types.Int64Value(0)
 	}
 	plan.ID = types.StringValue(formatStackID(env, name))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if readyErr != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("wait_for_ready"), "Dockhand stack did not become ready", readyErr.Error())
+	}
 }
 
 func (r *stackResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -173,7 +223,7 @@ func (r *stackResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	stack, found, err := r.client.GetStackByName(ctx, state.Env.ValueString(), state.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Dockhand stack", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error reading Dockhand stack", err)
 		return
 	}
 	if !found {
@@ -181,9 +231,10 @@ func (r *stackResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	// Keep configured compose if API doesn't return it in list responses.
+	// Keep configured compose if API doesn't return it in list responses. An
+	// equivalent manifest keeps the prior formatting through composeType.
 	if stack.Compose != "" {
-		state.Compose = types.StringValue(stack.Compose)
+		state.Compose = newComposeValue(stack.Compose)
 	}
 	state.Status = types.StringValue(stack.Status)
 	state.ContainerIDs = stringSliceToListValue(stack.Containers)
@@ -206,26 +257,85 @@ func (r *stackResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	if plan.Enabled.ValueBool() == state.Enabled.ValueBool() {
-		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultStackTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	env := plan.Env.ValueString()
 	name := plan.Name.ValueString()
+	waitForReady := plan.Enabled.ValueBool() && plan.WaitForReady.ValueBool()
+
+	// The containers running before the update tell readiness which ones
+	// `compose up` still has to replace.
+	var baseline stackDeployBaseline
+	if waitForReady {
+		var err error
+		baseline, err = captureStackBaseline(ctx, r.client, env, name, state.Compose.ValueString())
+		if err != nil {
+			addAPIError(&resp.Diagnostics, path.Root("wait_for_ready"), "Error reading Dockhand stack before update", err)
+			return
+		}
+	}
 
-	var err error
-	if plan.Enabled.ValueBool() {
-		err = r.client.StartStack(ctx, env, name)
-	} else {
-		err = r.client.StopStack(ctx, env, name)
+	// A reformatted manifest only updates state.
+	if !composeSemanticallyEqual(plan.Compose.ValueString(), state.Compose.ValueString()) {
+		if _, err := r.client.UpdateStackCompose(ctx, env, name, plan.Compose.ValueString()); err != nil {
+			addAPIError(&resp.Diagnostics, path.Root("name"), "Error updating Dockhand stack compose", err)
+			return
+		}
+
+		// Starting an already running stack runs `compose up`, which recreates
+		// only the services whose definition changed. A stack that is being
+		// enabled in this same update is started by the runtime toggle below.
+		if plan.Enabled.ValueBool() && state.Enabled.ValueBool() {
+			if err := r.client.StartStack(ctx, env, name); err != nil {
+				addAPIError(&resp.Diagnostics, path.Root("name"), "Error redeploying Dockhand stack after compose update", err)
+				return
+			}
+		}
 	}
+
+	if plan.Enabled.ValueBool() != state.Enabled.ValueBool() {
+		var err error
+		if plan.Enabled.ValueBool() {
+			err = r.client.StartStack(ctx, env, name)
+		} else {
+			err = r.client.StopStack(ctx, env, name)
+		}
+		if err != nil {
+			addAPIError(&resp.Diagnostics, path.Root("name"), "Error updating Dockhand stack runtime state", err)
+			return
+		}
+	}
+
+	var readyErr error
+	if waitForReady {
+		readyErr = waitForStackReady(ctx, r.client, env, name, plan.Compose.ValueString(), baseline)
+	}
+
+	stack, found, err := r.client.GetStackByName(context.WithoutCancel(ctx), env, name)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating Dockhand stack runtime state", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("name"), "Error reading Dockhand stack after update", err)
 		return
 	}
-
+	if found {
+		plan.Status = types.StringValue(stack.Status)
+		plan.ContainerIDs = stringSliceToListValue(stack.Containers)
+		plan.ContainerCount = types.Int64Value(int64(len(stack.Containers)))
+	} else {
+		plan.Status = state.Status
+		plan.ContainerIDs = state.ContainerIDs
+		plan.ContainerCount = state.ContainerCount
+	}
+	plan.ID = state.ID
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if readyErr != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("wait_for_ready"), "Dockhand stack did not become ready", readyErr.Error())
+	}
 }
 
 func (r *stackResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -240,12 +350,20 @@ func (r *stackResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	status, err := r.client.DeleteStack(ctx, state.Env.ValueString(), state.Name.ValueString())
-	if err != nil && status != 404 {
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultStackTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	_, err := r.client.DeleteStack(ctx, state.Env.ValueString(), state.Name.ValueString())
+	if err != nil && !IsNotFound(err) {
 		// Dockhand may return non-2xx with a successful backend remove.
 		_, found, readErr := r.client.GetStackByName(ctx, state.Env.ValueString(), state.Name.ValueString())
 		if readErr != nil || found {
-			resp.Diagnostics.AddError("Error deleting Dockhand stack", err.Error())
+			addAPIError(&resp.Diagnostics, path.Root("id"), "Error deleting Dockhand stack", err)
 			return
 		}
 	}
@@ -130,7 +130,7 @@ func (r *stackActionResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Error running Dockhand stack action", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error running Dockhand stack action", err)
 		return
 	}
 
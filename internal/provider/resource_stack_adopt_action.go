@@ -115,7 +115,7 @@ func (r *stackAdoptActionResource) Create(ctx context.Context, req resource.Crea
 		Stacks:        items,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Error adopting stacks", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error adopting stacks", err)
 		return
 	}
 	if status < 200 || status > 299 {
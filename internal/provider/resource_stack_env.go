@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -250,7 +251,7 @@ func (r *stackEnvResource) Create(ctx context.Context, req resource.CreateReques
 	}
 
 	if _, err := r.upsert(ctx, env, stackName, raw, planVars); err != nil {
-		resp.Diagnostics.AddError("Error updating stack env", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("stack_name"), "Error updating stack env", err)
 		return
 	}
 
@@ -281,11 +282,11 @@ func (r *stackEnvResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	raw, status, err := r.client.GetStackEnvRaw(ctx, env, stackName)
 	if err != nil {
-		if status == http.StatusNotFound {
+		if IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading stack env raw content", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error reading stack env raw content", err)
 		return
 	}
 	if status == http.StatusNotFound {
@@ -295,11 +296,11 @@ func (r *stackEnvResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	vars, status, err := r.client.GetStackEnvVars(ctx, env, stackName)
 	if err != nil {
-		if status == http.StatusNotFound {
+		if IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading stack env variables", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error reading stack env variables", err)
 		return
 	}
 
@@ -344,7 +345,7 @@ func (r *stackEnvResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 
 	if _, err := r.upsert(ctx, plan.Env.ValueString(), plan.StackName.ValueString(), raw, planVars); err != nil {
-		resp.Diagnostics.AddError("Error updating stack env", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("stack_name"), "Error updating stack env", err)
 		return
 	}
 
@@ -370,15 +371,15 @@ func (r *stackEnvResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	status, err := r.client.UpdateStackEnvRaw(ctx, state.Env.ValueString(), state.StackName.ValueString(), "")
-	if err != nil && status != http.StatusNotFound {
-		resp.Diagnostics.AddError("Error clearing stack env raw content", err.Error())
+	_, err := r.client.UpdateStackEnvRaw(ctx, state.Env.ValueString(), state.StackName.ValueString(), "")
+	if err != nil && !IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error clearing stack env raw content", err)
 		return
 	}
 
-	status, err = r.client.UpdateStackEnvVars(ctx, state.Env.ValueString(), state.StackName.ValueString(), []stackEnvVariable{})
-	if err != nil && status != http.StatusNotFound {
-		resp.Diagnostics.AddError("Error clearing stack env variables", err.Error())
+	_, err = r.client.UpdateStackEnvVars(ctx, state.Env.ValueString(), state.StackName.ValueString(), []stackEnvVariable{})
+	if err != nil && !IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error clearing stack env variables", err)
 		return
 	}
 }
@@ -85,7 +85,7 @@ func (r *stackScanActionResource) Create(ctx context.Context, req resource.Creat
 
 	result, status, err := r.client.ScanStacks(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error scanning stacks", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error scanning stacks", err)
 		return
 	}
 	if status < 200 || status > 299 {
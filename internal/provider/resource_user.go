@@ -137,7 +137,7 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 	payload := buildUserPayload(plan)
 	created, err := r.client.CreateUser(ctx, payload)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating Dockhand user", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error creating Dockhand user", err)
 		return
 	}
 
@@ -147,7 +147,7 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 	if userNeedsReconcile(plan, created) {
 		updated, err := r.client.UpdateUser(ctx, fmt.Sprintf("%d", created.ID), buildUserPayload(plan))
 		if err != nil {
-			resp.Diagnostics.AddError("Error reconciling Dockhand user after create", err.Error())
+			addAPIError(&resp.Diagnostics, path.Empty(), "Error reconciling Dockhand user after create", err)
 			return
 		}
 		reconciled = updated
@@ -169,13 +169,13 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	user, status, err := r.client.GetUser(ctx, state.ID.ValueString())
+	user, _, err := r.client.GetUser(ctx, state.ID.ValueString())
 	if err != nil {
-		if status == 404 {
+		if IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading Dockhand user", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error reading Dockhand user", err)
 		return
 	}
 
@@ -205,7 +205,7 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	updated, err := r.client.UpdateUser(ctx, id, buildUserPayload(plan))
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating Dockhand user", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error updating Dockhand user", err)
 		return
 	}
 
@@ -225,9 +225,9 @@ func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	status, err := r.client.DeleteUser(ctx, state.ID.ValueString())
-	if err != nil && status != 404 {
-		resp.Diagnostics.AddError("Error deleting Dockhand user", err.Error())
+	_, err := r.client.DeleteUser(ctx, state.ID.ValueString())
+	if err != nil && !IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error deleting Dockhand user", err)
 		return
 	}
 }
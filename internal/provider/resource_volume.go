@@ -70,7 +70,7 @@ func (r *volumeResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				},
 			},
 			"env": schema.StringAttribute{
-				MarkdownDescription: "Optional environment ID. If omitted, provider `default_env` is used.",
+				MarkdownDescription: "Optional environment ID or name. If omitted, provider `default_env` is used.",
 				Optional:            true,
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
@@ -160,13 +160,13 @@ func (r *volumeResource) Create(ctx context.Context, req resource.CreateRequest,
 		Labels:     labels,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating Dockhand volume", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("name"), "Error creating Dockhand volume", err)
 		return
 	}
 
-	vol, status, err := r.client.GetVolumeInspect(ctx, env, name)
-	if err != nil && status != 404 {
-		resp.Diagnostics.AddError("Error reading created Dockhand volume", err.Error())
+	vol, _, err := r.client.GetVolumeInspect(ctx, env, name)
+	if err != nil && !IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, path.Root("name"), "Error reading created Dockhand volume", err)
 		return
 	}
 
@@ -203,13 +203,13 @@ func (r *volumeResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	vol, status, err := r.client.GetVolumeInspect(ctx, strings.TrimSpace(state.Env.ValueString()), state.Name.ValueString())
+	vol, _, err := r.client.GetVolumeInspect(ctx, strings.TrimSpace(state.Env.ValueString()), state.Name.ValueString())
 	if err != nil {
-		if status == 404 {
+		if IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading Dockhand volume", err.Error())
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error reading Dockhand volume", err)
 		return
 	}
 
@@ -239,9 +239,9 @@ func (r *volumeResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	status, err := r.client.DeleteVolume(ctx, strings.TrimSpace(state.Env.ValueString()), state.Name.ValueString())
-	if err != nil && status != 404 {
-		resp.Diagnostics.AddError("Error deleting Dockhand volume", err.Error())
+	_, err := r.client.DeleteVolume(ctx, strings.TrimSpace(state.Env.ValueString()), state.Name.ValueString())
+	if err != nil && !IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, path.Root("id"), "Error deleting Dockhand volume", err)
 		return
 	}
 }
@@ -111,7 +111,7 @@ func (r *volumeCloneActionResource) Create(ctx context.Context, req resource.Cre
 
 	status, err := r.client.CloneVolume(ctx, plan.Env.ValueString(), sourceName, targetName)
 	if err != nil {
-		resp.Diagnostics.AddError("Error cloning Dockhand volume", err.Error())
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error cloning Dockhand volume", err)
 		return
 	}
 	if status < 200 || status > 299 {
@@ -146,9 +146,9 @@ func (r *volumeCloneActionResource) Delete(ctx context.Context, req resource.Del
 		return
 	}
 
-	status, err := r.client.DeleteVolume(ctx, strings.TrimSpace(state.Env.ValueString()), strings.TrimSpace(state.TargetName.ValueString()))
-	if err != nil && status != 404 {
-		resp.Diagnostics.AddError("Error deleting cloned Dockhand volume", err.Error())
+	_, err := r.client.DeleteVolume(ctx, strings.TrimSpace(state.Env.ValueString()), strings.TrimSpace(state.TargetName.ValueString()))
+	if err != nil && !IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, path.Empty(), "Error deleting cloned Dockhand volume", err)
 		return
 	}
 }
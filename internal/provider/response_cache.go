@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// responseCache keeps raw response bodies of read-only list
+// endpoints for a short TTL and coalesces concurrent identical GETs, so
+// resources refreshing in parallel share one request per environment.
+// It is disabled unless `response_cache_ttl` is set, except for reads
+// marked withSharedResponse.
+type responseCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[responseCacheKey]*responseCacheEntry
+}
+
+type responseCacheKey struct {
+	env   string
+	path  string
+	query string
+}
+
+type responseCacheEntry struct {
+	// done is closed once the request finished; body, status and err are
+	// only read after that.
+	done    chan struct{}
+	body    json.RawMessage
+	status  int
+	err     error
+	expires time.Time
+}
+
+// sharedResponseTTL bounds reads marked withSharedResponse when
+// `response_cache_ttl` is unset. It spans a typical plan or apply; mutations
+// still invalidate them right away.
+const sharedResponseTTL = 5 * time.Minute
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{ttl: ttl, entries: map[responseCacheKey]*responseCacheEntry{}}
+}
+
+type cachedResponseKey struct{}
+
+type uncachedResponseKey struct{}
+
+type sharedResponseKey struct{}
+
+// withCachedResponse marks a GET of a read-only list endpoint as cacheable.
+func withCachedResponse(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cachedResponseKey{}, true)
+}
+
+// withoutResponseCache forces fresh reads, for polling loops that wait for
+// a change made outside the provider.
+func withoutResponseCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, uncachedResponseKey{}, true)
+}
+
+// withSharedResponse marks a GET whose result many resources need during
+// one run. It is cached even when `response_cache_ttl` is unset, for
+// sharedResponseTTL.
+func withSharedResponse(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sharedResponseKey{}, true)
+}
+
+// responseCacheFor returns the cache that serves a GET with ctx, or nil
+// when it must hit the API.
+func (c *Client) responseCacheFor(ctx context.Context) *responseCache {
+	if uncached, _ := ctx.Value(uncachedResponseKey{}).(bool); uncached {
+		return nil
+	}
+	cached, _ := ctx.Value(cachedResponseKey{}).(bool)
+	shared, _ := ctx.Value(sharedResponseKey{}).(bool)
+	switch {
+	case (cached || shared) && c.cache != nil:
+		return c.cache
+	case shared:
+		return c.sharedCache
+	default:
+		return nil
+	}
+}
+
+// SetResponseCacheTTL enables the list response cache. A zero TTL disables it.
+func (c *Client) SetResponseCacheTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		c.cache = nil
+		return
+	}
+	c.cache = newResponseCache(ttl)
+}
+
+// cachedGet serves a cacheable GET from cache, joining an identical request
+// that is already in flight. query must already carry the resolved
+// environment ID.
+func (c *Client) cachedGet(ctx context.Context, cache *responseCache, path string, query map[string]string, out any) (int, error) {
+	values := url.Values{}
+	for k, v := range query {
+		if v != "" {
+			values.Set(k, v)
+		}
+	}
+	key := responseCacheKey{env: requestEnv(query), path: path, query: values.Encode()}
+
+	// Requests always run without the cache marker so they hit the API.
+	fetch := withoutResponseCache(ctx)
+	for {
+		entry, leader := cache.acquire(key)
+		if leader {
+			var body json.RawMessage
+			entry.status, entry.err = c.doJSONWithStatus(fetch, http.MethodGet, path, query, nil, &body)
+			entry.body = body
+			entry.expires = time.Now().Add(cache.ttl)
+			cache.release(key, entry)
+		} else {
+			select {
+			case <-entry.done:
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+			// The leader's own deadline is not ours; retry instead of
+			// passing on its cancellation.
+			if entry.err != nil && (errors.Is(entry.err, context.Canceled) || errors.Is(entry.err, context.DeadlineExceeded)) && ctx.Err() == nil {
+				continue
+			}
+		}
+
+		if entry.err != nil {
+			return entry.status, entry.err
+		}
+		if out != nil && len(entry.body) > 0 {
+			if err := json.Unmarshal(entry.body, out); err != nil {
+				return entry.status, err
+			}
+		}
+		return entry.status, nil
+	}
+}
+
+// acquire returns the fresh or in-flight entry for key, or registers a new
+// one and reports that the caller must perform the request.
+func (rc *responseCache) acquire(key responseCacheKey) (*responseCacheEntry, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if entry, ok := rc.entries[key]; ok {
+		select {
+		case <-entry.done:
+			if time.Now().Before(entry.expires) {
+				return entry, false
+			}
+		default:
+			return entry, false
+		}
+	}
+	entry := &responseCacheEntry{done: make(chan struct{})}
+	rc.entries[key] = entry
+	return entry, true
+}
+
+// release publishes a finished request. Failed requests are not kept, and
+// an entry that was invalidated while in flight is not stored.
+func (rc *responseCache) release(key responseCacheKey, entry *responseCacheEntry) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	close(entry.done)
+	if entry.err != nil && rc.entries[key] == entry {
+		delete(rc.entries, key)
+	}
+}
+
+// invalidate drops entries of env plus those without an environment. An
+// empty env drops everything.
+func (rc *responseCache) invalidate(env string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for key := range rc.entries {
+		if env == "" || key.env == env || key.env == "" {
+			delete(rc.entries, key)
+		}
+	}
+}
+
+func requestEnv(query map[string]string) string {
+	if env := query["env"]; env != "" {
+		return env
+	}
+	return query["envId"]
+}
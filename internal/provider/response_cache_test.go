@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newResponseCacheTestServer(t *testing.T, lists *atomic.Int32, release <-chan struct{}) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/networks":
+			lists.Add(1)
+			if release != nil {
+				<-release
+			}
+			_, _ = w.Write([]byte(`[{"id":"n1","name":"` + r.URL.Query().Get("env") + `"}]`))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestResponseCacheReusesAndInvalidates(t *testing.T) {
+	t.Parallel()
+
+	var lists atomic.Int32
+	server := newResponseCacheTestServer(t, &lists, nil)
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "", "1", true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	client.SetResponseCacheTTL(time.Minute)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		networks, _, err := client.ListNetworks(ctx, "1")
+		if err != nil || len(networks) != 1 || networks[0].Name != "1" {
+			t.Fatalf("unexpected list result %+v, err %v", networks, err)
+		}
+	}
+	if lists.Load() != 1 {
+		t.Fatalf("expected one list request, got %d", lists.Load())
+	}
+
+	if _, _, err := client.ListNetworks(ctx, "2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lists.Load() != 2 {
+		t.Fatalf("expected environments to be cached separately, got %d requests", lists.Load())
+	}
+
+	// A change in environment 2 leaves environment 1 cached.
+	if _, err := client.DeleteNetwork(ctx, "2", "n1"); err != nil {
+		t.Fatalf("unexpected error deleting network: %v", err)
+	}
+	if _, _, err := client.ListNetworks(ctx, "1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lists.Load() != 2 {
+		t.Fatalf("expected environment 1 to stay cached, got %d requests", lists.Load())
+	}
+
+	if _, err := client.DeleteNetwork(ctx, "1", "n1"); err != nil {
+		t.Fatalf("unexpected error deleting network: %v", err)
+	}
+	if _, _, err := client.ListNetworks(ctx, "1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lists.Load() != 3 {
+		t.Fatalf("expected mutation to invalidate environment 1, got %d requests", lists.Load())
+	}
+
+	if _, _, err := client.ListNetworks(withoutResponseCache(ctx), "1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lists.Load() != 4 {
+		t.Fatalf("expected uncached read to reach the API, got %d requests", lists.Load())
+	}
+}
+
+func TestResponseCacheCoalescesConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	var lists atomic.Int32
+	release := make(chan struct{})
+	server := newResponseCacheTestServer(t, &lists, release)
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "", "1", true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	client.SetResponseCacheTTL(time.Minute)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := client.ListNetworks(context.Background(), "1")
+			errs <- err
+		}()
+	}
+	for lists.Load() == 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+	// Give the other callers time to join the in-flight request.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if lists.Load() != 1 {
+		t.Fatalf("expected concurrent requests to be coalesced, got %d", lists.Load())
+	}
+}
+
+func TestResponseCacheDisabledByDefault(t *testing.T) {
+	t.Parallel()
+
+	var lists atomic.Int32
+	server := newResponseCacheTestServer(t, &lists, nil)
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "", "1", true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.ListNetworks(context.Background(), "1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if lists.Load() != 2 {
+		t.Fatalf("expected every list to reach the API, got %d", lists.Load())
+	}
+}
@@ -0,0 +1,384 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"gopkg.in/yaml.v3"
+)
+
+// composeTopLevelKeys are the top-level keys allowed by the Compose
+// specification. Extension keys (`x-*`) are accepted as well.
+var composeTopLevelKeys = map[string]struct{}{
+	"version":  {},
+	"name":     {},
+	"include":  {},
+	"services": {},
+	"networks": {},
+	"volumes":  {},
+	"configs":  {},
+	"secrets":  {},
+	"models":   {},
+}
+
+// validateComposeManifest checks a compose manifest for problems that can be
+// detected without Docker: YAML syntax, top-level structure, services without
+// an image or build, references to undeclared services/networks/volumes and
+// host ports published more than once. Values using `${...}` interpolation
+// are skipped because they are only known on the Dockhand host.
+func validateComposeManifest(content string) []string {
+	var doc map[string]any
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return []string{fmt.Sprintf("compose is not valid YAML: %s", err)}
+	}
+	if doc == nil {
+		return []string{"compose is empty; expected a mapping with a top-level `services` key"}
+	}
+
+	var problems []string
+	for _, key := range sortedKeys(doc) {
+		if _, ok := composeTopLevelKeys[key]; !ok && !strings.HasPrefix(key, "x-") {
+			problems = append(problems, fmt.Sprintf("unknown top-level key %q", key))
+		}
+	}
+
+	rawServices, hasServices := doc["services"]
+	if !hasServices {
+		if _, hasInclude := doc["include"]; !hasInclude {
+			problems = append(problems, "missing top-level `services` key")
+		}
+		return problems
+	}
+	services, ok := rawServices.(map[string]any)
+	if !ok {
+		return append(problems, "`services` must be a mapping of service name to definition")
+	}
+
+	declaredNetworks := topLevelNames(doc, "networks")
+	declaredNetworks["default"] = struct{}{}
+	declaredVolumes := topLevelNames(doc, "volumes")
+
+	publishedBy := map[string]string{}
+	for _, name := range sortedKeys(services) {
+		service, ok := services[name].(map[string]any)
+		if !ok {
+			if services[name] == nil {
+				problems = append(problems, fmt.Sprintf("service %q has an empty definition", name))
+			} else {
+				problems = append(problems, fmt.Sprintf("service %q must be a mapping", name))
+			}
+			continue
+		}
+
+		_, hasImage := service["image"]
+		_, hasBuild := service["build"]
+		if !hasImage && !hasBuild {
+			problems = append(problems, fmt.Sprintf("service %q sets neither `image` nor `build`", name))
+		}
+
+		for _, dep := range composeDependsOn(service["depends_on"]) {
+			if _, ok := services[dep]; !ok {
+				problems = append(problems, fmt.Sprintf("service %q depends on undefined service %q", name, dep))
+			}
+		}
+
+		if _, ok := service["network_mode"]; !ok {
+			for _, network := range composeServiceNetworks(service["networks"]) {
+				if _, ok := declaredNetworks[network]; !ok {
+					problems = append(problems, fmt.Sprintf("service %q uses network %q which is not declared under top-level `networks`", name, network))
+				}
+			}
+		}
+
+		for _, volume := range composeNamedVolumes(service["volumes"]) {
+			if _, ok := declaredVolumes[volume]; !ok {
+				problems = append(problems, fmt.Sprintf("service %q mounts named volume %q which is not declared under top-level `volumes`", name, volume))
+			}
+		}
+
+		ports, _ := service["ports"].([]any)
+		for _, port := range ports {
+			bindings, err := composePublishedPorts(port)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("service %q: %s", name, err))
+				continue
+			}
+			for _, binding := range bindings {
+				if owner, seen := publishedBy[binding]; seen {
+					problems = append(problems, fmt.Sprintf("host port %s is published by both service %q and service %q", binding, owner, name))
+					continue
+				}
+				publishedBy[binding] = name
+			}
+		}
+	}
+
+	return problems
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func topLevelNames(doc map[string]any, key string) map[string]struct{} {
+	out := map[string]struct{}{}
+	if entries, ok := doc[key].(map[string]any); ok {
+		for name := range entries {
+			out[name] = struct{}{}
+		}
+	}
+	return out
+}
+
+func composeDependsOn(raw any) []string {
+	var out []string
+	switch v := raw.(type) {
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+	case map[string]any:
+		out = sortedKeys(v)
+	}
+	return out
+}
+
+func composeServiceNetworks(raw any) []string {
+	var out []string
+	switch v := raw.(type) {
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok && !strings.Contains(s, "$") {
+				out = append(out, s)
+			}
+		}
+	case map[string]any:
+		for _, name := range sortedKeys(v) {
+			if !strings.Contains(name, "$") {
+				out = append(out, name)
+			}
+		}
+	}
+	return out
+}
+
+// composeNamedVolumes returns the named volumes (not bind mounts) used by a service.
+func composeNamedVolumes(raw any) []string {
+	items, _ := raw.([]any)
+	var out []string
+	for _, item := range items {
+		var source string
+		switch v := item.(type) {
+		case string:
+			parts := strings.Split(v, ":")
+			if len(parts) < 2 {
+				continue
+			}
+			source = parts[0]
+		case map[string]any:
+			if t, _ := v["type"].(string); t != "" && t != "volume" {
+				continue
+			}
+			source, _ = v["source"].(string)
+		}
+		if source == "" || strings.Contains(source, "$") || strings.ContainsAny(source[:1], "/.~") {
+			continue
+		}
+		out = append(out, source)
+	}
+	return out
+}
+
+// composePublishedPorts expands one `ports` entry into "ip:port/proto" keys
+// for each host port it publishes.
+func composePublishedPorts(raw any) ([]string, error) {
+	var hostIP, published, protocol string
+	switch v := raw.(type) {
+	case string:
+		if strings.Contains(v, "$") {
+			return nil, nil
+		}
+		spec := v
+		protocol = "tcp"
+		if idx := strings.LastIndex(spec, "/"); idx >= 0 {
+			protocol = spec[idx+1:]
+			spec = spec[:idx]
+		}
+		// IPv6 host IPs are written in brackets, e.g. "[::1]:8080:80".
+		if strings.HasPrefix(spec, "[") {
+			end := strings.Index(spec, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid port mapping %q", v)
+			}
+			hostIP = spec[1:end]
+			spec = strings.TrimPrefix(spec[end+1:], ":")
+		}
+		parts := strings.Split(spec, ":")
+		switch len(parts) {
+		case 1:
+			return nil, nil
+		case 2:
+			published = parts[0]
+		case 3:
+			hostIP, published = parts[0], parts[1]
+		default:
+			return nil, fmt.Errorf("invalid port mapping %q", v)
+		}
+	case map[string]any:
+		published = fmt.Sprint(valueOrEmpty(v["published"]))
+		hostIP = fmt.Sprint(valueOrEmpty(v["host_ip"]))
+		protocol = fmt.Sprint(valueOrEmpty(v["protocol"]))
+		if protocol == "" {
+			protocol = "tcp"
+		}
+		if strings.Contains(published, "$") || strings.Contains(hostIP, "$") {
+			return nil, nil
+		}
+	default:
+		return nil, nil
+	}
+
+	if published == "" {
+		return nil, nil
+	}
+	if hostIP == "" {
+		hostIP = "0.0.0.0"
+	}
+
+	start, end, err := parsePortRange(published)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]string, 0, end-start+1)
+	for port := start; port <= end; port++ {
+		out = append(out, fmt.Sprintf("%s:%d/%s", hostIP, port, protocol))
+	}
+	return out, nil
+}
+
+func valueOrEmpty(v any) any {
+	if v == nil {
+		return ""
+	}
+	return v
+}
+
+func parsePortRange(value string) (int, int, error) {
+	first, last, isRange := strings.Cut(value, "-")
+	start, err := strconv.Atoi(first)
+	if err != nil || start < 1 || start > 65535 {
+		return 0, 0, fmt.Errorf("invalid host port %q", value)
+	}
+	if !isRange {
+		return start, start, nil
+	}
+	end, err := strconv.Atoi(last)
+	if err != nil || end < start || end > 65535 {
+		return 0, 0, fmt.Errorf("invalid host port range %q", value)
+	}
+	return start, end, nil
+}
+
+// composeSemanticallyEqual reports whether two manifests decode to the same
+// YAML document, ignoring formatting, comments and mapping key order.
+func composeSemanticallyEqual(a string, b string) bool {
+	if a == b {
+		return true
+	}
+	var left, right any
+	if err := yaml.Unmarshal([]byte(a), &left); err != nil {
+		return false
+	}
+	if err := yaml.Unmarshal([]byte(b), &right); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(left, right)
+}
+
+// composeType is the string type of `dockhand_stack.compose`. Its values
+// are semantically equal when they decode to the same YAML document, so
+// Dockhand returning an equivalent manifest, or a configuration that is only
+// reformatted, keeps the prior text in state.
+type composeType struct {
+	basetypes.StringType
+}
+
+var _ basetypes.StringTypable = composeType{}
+
+func (t composeType) Equal(o attr.Type) bool {
+	other, ok := o.(composeType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t composeType) String() string {
+	return "composeType"
+}
+
+func (t composeType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return composeValue{StringValue: in}, nil
+}
+
+func (t composeType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return composeValue{StringValue: stringValue}, nil
+}
+
+func (t composeType) ValueType(_ context.Context) attr.Value {
+	return composeValue{}
+}
+
+// composeValue holds a compose manifest; see composeType.
+type composeValue struct {
+	basetypes.StringValue
+}
+
+var _ basetypes.StringValuableWithSemanticEquals = composeValue{}
+
+func newComposeValue(content string) composeValue {
+	return composeValue{StringValue: basetypes.NewStringValue(content)}
+}
+
+func (v composeValue) Equal(o attr.Value) bool {
+	other, ok := o.(composeValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v composeValue) Type(_ context.Context) attr.Type {
+	return composeType{}
+}
+
+func (v composeValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	newValue, ok := newValuable.(composeValue)
+	if !ok {
+		return false, nil
+	}
+	return composeSemanticallyEqual(v.ValueString(), newValue.ValueString()), nil
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestValidateComposeManifest(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		compose string
+		want    []string
+	}{
+		{
+			name: "valid",
+			compose: `
+services:
+  web:
+    image: nginx:1.27
+    ports: ["8080:80", "127.0.0.1:8443:443/tcp"]
+    networks: [front]
+    volumes: ["data:/usr/share/nginx/html", "./conf:/etc/nginx/conf.d:ro"]
+    depends_on: [db]
+  db:
+    image: postgres:16
+    ports:
+      - "${DB_PORT}:5432"
+networks:
+  front: {}
+volumes:
+  data: {}
+x-common: &common {}
+`,
+		},
+		{
+			name:    "invalid yaml",
+			compose: "services:\n  web:\n    image: [nginx\n",
+			want:    []string{"not valid YAML"},
+		},
+		{
+			name:    "missing services",
+			compose: "networks:\n  front: {}\n",
+			want:    []string{"missing top-level `services` key"},
+		},
+		{
+			name:    "unknown top-level key",
+			compose: "service:\n  web:\n    image: nginx\n",
+			want:    []string{`unknown top-level key "service"`, "missing top-level `services` key"},
+		},
+		{
+			name: "duplicate host ports",
+			compose: `
+services:
+  a:
+    image: nginx
+    ports: ["8080-8081:80-81"]
+  b:
+    image: nginx
+    ports:
+      - published: 8081
+        target: 80
+`,
+			want: []string{`host port 0.0.0.0:8081/tcp is published by both service "a" and service "b"`},
+		},
+		{
+			name: "undeclared references",
+			compose: `
+services:
+  web:
+    build: .
+    depends_on:
+      cache:
+        condition: service_started
+    networks: [back]
+    volumes: [logs:/var/log]
+  worker: {}
+`,
+			want: []string{
+				`service "web" depends on undefined service "cache"`,
+				`service "web" uses network "back"`,
+				`service "web" mounts named volume "logs"`,
+				`service "worker" sets neither`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := validateComposeManifest(tc.compose)
+			if len(got) != len(tc.want) {
+				t.Fatalf("expected %d problems, got %d: %q", len(tc.want), len(got), got)
+			}
+			for i, want := range tc.want {
+				if !strings.Contains(got[i], want) {
+					t.Fatalf("problem %d: expected %q to contain %q", i, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestComposeSemanticallyEqual(t *testing.T) {
+	t.Parallel()
+
+	a := "services:\n  web:\n    image: nginx\n    restart: always\n"
+	b := "# reformatted\nservices:\n    web: {restart: always,   image: nginx}\n"
+	c := "services:\n  web:\n    image: nginx\n    restart: unless-stopped\n"
+
+	if !composeSemanticallyEqual(a, b) {
+		t.Fatalf("expected reformatted manifests to be equal")
+	}
+	if composeSemanticallyEqual(a, c) {
+		t.Fatalf("expected changed restart policy to be a difference")
+	}
+	if composeSemanticallyEqual(a, "services: [") {
+		t.Fatalf("expected invalid YAML to never be equal")
+	}
+}
+
+func TestComposeValueSemanticEquals(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	prior := newComposeValue("services:\n  web:\n    image: nginx\n")
+
+	equal, diags := prior.StringSemanticEquals(ctx, newComposeValue("services: {web: {image: nginx}}\n"))
+	if diags.HasError() || !equal {
+		t.Fatalf("expected reformatted manifest to be semantically equal, got %t (%v)", equal, diags)
+	}
+	equal, _ = prior.StringSemanticEquals(ctx, newComposeValue("services:\n  web:\n    image: nginx:1.27\n"))
+	if equal {
+		t.Fatal("expected changed image to be a difference")
+	}
+
+	value, err := composeType{}.ValueFromTerraform(ctx, tftypes.NewValue(tftypes.String, "services: {}"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := value.(composeValue); !ok || !value.Type(ctx).Equal(composeType{}) {
+		t.Fatalf("expected composeValue of composeType, got %T", value)
+	}
+}
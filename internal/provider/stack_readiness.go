@@ -0,0 +1,258 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const stackReadyPollInterval = 3 * time.Second
+
+// stackDeployBaseline records a stack as it was before a deploy, so
+// readiness checks do not mistake the previous containers of a changed
+// service for the new ones.
+type stackDeployBaseline struct {
+	compose    string
+	containers []stackContainerDetailResponse
+}
+
+// captureStackBaseline reads the stack before a deploy. compose is the
+// manifest currently deployed when the caller knows it; otherwise the one
+// reported by Dockhand is used. A stack that does not exist yet has an
+// empty baseline.
+func captureStackBaseline(ctx context.Context, client *Client, env string, name string, compose string) (stackDeployBaseline, error) {
+	stack, found, err := client.GetStackByName(withoutResponseCache(ctx), env, name)
+	if err != nil || !found {
+		return stackDeployBaseline{compose: compose}, err
+	}
+	if compose == "" {
+		compose = stack.Compose
+	}
+	return stackDeployBaseline{compose: compose, containers: stack.ContainerDetails}, nil
+}
+
+// waitForStackReady polls the stack list until every service of compose is
+// running and, when it defines a healthcheck, healthy. Services that may
+// exit on success count as done once they exited with code 0. Services
+// whose definition changed since baseline only count with containers that
+// did not exist before the deploy. When compose is empty the manifest
+// reported by Dockhand is used. On timeout the error lists each service
+// that was not ready with its state and restart count.
+func waitForStackReady(ctx context.Context, client *Client, env string, name string, compose string, baseline stackDeployBaseline) error {
+	problems := []string{"stack has not been reported by Dockhand yet"}
+	timedOut := func() error {
+		return fmt.Errorf("timed out waiting for stack %q to become ready:\n  - %s", name, strings.Join(problems, "\n  - "))
+	}
+
+	for {
+		stack, found, err := client.GetStackByName(withoutResponseCache(ctx), env, name)
+		if err != nil {
+			if ctx.Err() != nil {
+				return timedOut()
+			}
+			return err
+		}
+		if found {
+			manifest := compose
+			if manifest == "" {
+				manifest = stack.Compose
+			}
+			problems = stackReadinessProblems(stack.ContainerDetails, composeServiceSpecs(manifest), staleStackContainers(baseline, manifest))
+			if len(problems) == 0 {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return timedOut()
+		case <-time.After(stackReadyPollInterval):
+		}
+	}
+}
+
+// stackServiceSpec is what readiness needs to know about a compose service.
+type stackServiceSpec struct {
+	// oneShot is set when the restart policy leaves a container stopped
+	// after a successful exit (`no`, the default, or `on-failure`).
+	oneShot    bool
+	definition any
+}
+
+// composeServiceSpecs returns the services `compose up` starts by default,
+// skipping those behind a profile or scaled to zero. It returns nil when the
+// manifest cannot be parsed or lists no services, in which case readiness
+// falls back to the containers Dockhand reports.
+func composeServiceSpecs(compose string) map[string]stackServiceSpec {
+	if strings.TrimSpace(compose) == "" {
+		return nil
+	}
+	var doc map[string]any
+	if err := yaml.Unmarshal([]byte(compose), &doc); err != nil {
+		return nil
+	}
+	services, _ := doc["services"].(map[string]any)
+	if len(services) == 0 {
+		return nil
+	}
+
+	specs := map[string]stackServiceSpec{}
+	for name, raw := range services {
+		service, _ := raw.(map[string]any)
+		if profiles, ok := service["profiles"].([]any); ok && len(profiles) > 0 {
+			continue
+		}
+		if composeServiceScale(service) == 0 {
+			continue
+		}
+		specs[name] = stackServiceSpec{oneShot: composeOneShotRestart(service["restart"]), definition: raw}
+	}
+	return specs
+}
+
+func composeServiceScale(service map[string]any) int {
+	if scale, ok := service["scale"].(int); ok {
+		return scale
+	}
+	if deploy, ok := service["deploy"].(map[string]any); ok {
+		if replicas, ok := deploy["replicas"].(int); ok {
+			return replicas
+		}
+	}
+	return 1
+}
+
+func composeOneShotRestart(raw any) bool {
+	switch v := raw.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case string:
+		policy := strings.ToLower(strings.TrimSpace(v))
+		return policy == "" || policy == "no" || policy == "on-failure" || strings.HasPrefix(policy, "on-failure:")
+	default:
+		return false
+	}
+}
+
+// staleStackContainers returns the IDs of baseline containers belonging to
+// services whose definition differs in compose; `compose up` replaces those
+// containers. Without both manifests nothing is considered stale.
+func staleStackContainers(baseline stackDeployBaseline, compose string) map[string]struct{} {
+	before := composeServiceSpecs(baseline.compose)
+	after := composeServiceSpecs(compose)
+	if before == nil || after == nil {
+		return nil
+	}
+
+	stale := map[string]struct{}{}
+	for _, container := range baseline.containers {
+		service := stackContainerService(container)
+		previous, hadService := before[service]
+		current, hasService := after[service]
+		if hadService && hasService && !reflect.DeepEqual(previous.definition, current.definition) && container.ID != "" {
+			stale[container.ID] = struct{}{}
+		}
+	}
+	return stale
+}
+
+func stackContainerService(detail stackContainerDetailResponse) string {
+	if service := strings.TrimSpace(detail.Service); service != "" {
+		return service
+	}
+	return strings.TrimPrefix(strings.TrimSpace(detail.Name), "/")
+}
+
+var exitedStatusPattern = regexp.MustCompile(`(?i)^exited \((-?\d+)\)`)
+
+// containerExitCode parses the exit code from a Docker status such as
+// "Exited (0) 5 seconds ago".
+func containerExitCode(status string) (int, bool) {
+	match := exitedStatusPattern.FindStringSubmatch(strings.TrimSpace(status))
+	if match == nil {
+		return 0, false
+	}
+	code, err := strconv.Atoi(match[1])
+	return code, err == nil
+}
+
+// stackReadinessProblems describes every expected service that has no
+// container from this deploy yet, or whose containers are not running, not
+// healthy when a healthcheck reports a status, or (for one-shot services)
+// did not exit successfully. With no expected services every reported
+// service is checked. Containers in stale are ignored. An empty result means
+// the stack is ready.
+func stackReadinessProblems(details []stackContainerDetailResponse, expected map[string]stackServiceSpec, stale map[string]struct{}) []string {
+	if len(details) == 0 && expected == nil {
+		return []string{"stack has no containers yet"}
+	}
+
+	byService := map[string][]stackContainerDetailResponse{}
+	staleCount := map[string]int{}
+	for _, detail := range details {
+		service := stackContainerService(detail)
+		if _, ok := stale[detail.ID]; ok {
+			staleCount[service]++
+			continue
+		}
+		byService[service] = append(byService[service], detail)
+	}
+
+	var services []string
+	if expected != nil {
+		for service := range expected {
+			services = append(services, service)
+		}
+	} else {
+		for service := range byService {
+			services = append(services, service)
+		}
+	}
+	sort.Strings(services)
+
+	var problems []string
+	for _, service := range services {
+		containers := byService[service]
+		if len(containers) == 0 {
+			if staleCount[service] > 0 {
+				problems = append(problems, fmt.Sprintf("service %q: waiting for its container to be recreated (%d from before the deploy still present)", service, staleCount[service]))
+			} else {
+				problems = append(problems, fmt.Sprintf("service %q: no container created yet", service))
+			}
+			continue
+		}
+
+		for _, container := range containers {
+			state := strings.ToLower(strings.TrimSpace(container.State))
+			health := strings.ToLower(strings.TrimSpace(container.Health))
+			if state == "running" && (health == "" || health == "none" || health == "healthy") {
+				continue
+			}
+			if state == "exited" && expected[service].oneShot {
+				if code, ok := containerExitCode(container.Status); ok && code == 0 {
+					continue
+				}
+			}
+
+			problem := fmt.Sprintf("service %q: state %q", service, container.State)
+			if state == "exited" && container.Status != "" {
+				problem = fmt.Sprintf("service %q: %s", service, container.Status)
+			}
+			if health != "" && health != "none" {
+				problem += fmt.Sprintf(", health %q", container.Health)
+			}
+			problem += fmt.Sprintf(", %d restarts", container.RestartCount)
+			problems = append(problems, problem)
+		}
+	}
+	return problems
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStackReadinessProblems(t *testing.T) {
+	if problems := stackReadinessProblems(nil, nil, nil); len(problems) != 1 {
+		t.Fatalf("expected a stack without containers to be not ready, got %#v", problems)
+	}
+
+	ready := []stackContainerDetailResponse{
+		{Service: "web", State: "running", Health: "healthy"},
+		{Service: "worker", State: "running"},
+	}
+	if problems := stackReadinessProblems(ready, nil, nil); len(problems) != 0 {
+		t.Fatalf("expected ready stack, got %#v", problems)
+	}
+
+	problems := stackReadinessProblems([]stackContainerDetailResponse{
+		{Service: "web", State: "running", Health: "starting"},
+		{Service: "db", State: "restarting", RestartCount: 4},
+		{Name: "/cache", State: "running", Health: "healthy"},
+	}, nil, nil)
+	if len(problems) != 2 {
+		t.Fatalf("expected 2 problems, got %#v", problems)
+	}
+	if !strings.Contains(problems[0], `service "db"`) || !strings.Contains(problems[0], "4 restarts") {
+		t.Fatalf("unexpected db problem: %q", problems[0])
+	}
+	if !strings.Contains(problems[1], `service "web"`) || !strings.Contains(problems[1], `health "starting"`) {
+		t.Fatalf("unexpected web problem: %q", problems[1])
+	}
+}
+
+func TestStackReadinessUsesComposeServices(t *testing.T) {
+	compose := `
+services:
+  web:
+    image: nginx
+    restart: unless-stopped
+  migrate:
+    image: app
+    command: migrate
+  worker:
+    image: app
+    restart: always
+  debug:
+    image: busybox
+    profiles: [debug]
+`
+	expected := composeServiceSpecs(compose)
+	if len(expected) != 3 || !expected["migrate"].oneShot || expected["web"].oneShot {
+		t.Fatalf("unexpected service specs %#v", expected)
+	}
+
+	// A partly created stack is not ready.
+	problems := stackReadinessProblems([]stackContainerDetailResponse{
+		{ID: "w1", Service: "web", State: "running"},
+	}, expected, nil)
+	if len(problems) != 2 || !strings.Contains(problems[0], `service "migrate": no container created yet`) || !strings.Contains(problems[1], `service "worker"`) {
+		t.Fatalf("expected missing services to be reported, got %#v", problems)
+	}
+
+	// A one-shot service that exited successfully is complete; an
+	// always-restarting one that exited is not.
+	problems = stackReadinessProblems([]stackContainerDetailResponse{
+		{ID: "w1", Service: "web", State: "running"},
+		{ID: "m1", Service: "migrate", State: "exited", Status: "Exited (0) 3 seconds ago"},
+		{ID: "k1", Service: "worker", State: "exited", Status: "Exited (0) 3 seconds ago"},
+	}, expected, nil)
+	if len(problems) != 1 || !strings.Contains(problems[0], `service "worker": Exited (0)`) {
+		t.Fatalf("expected only the worker to be reported, got %#v", problems)
+	}
+
+	problems = stackReadinessProblems([]stackContainerDetailResponse{
+		{ID: "w1", Service: "web", State: "running"},
+		{ID: "m1", Service: "migrate", State: "exited", Status: "Exited (1) 3 seconds ago"},
+		{ID: "k1", Service: "worker", State: "running"},
+	}, expected, nil)
+	if len(problems) != 1 || !strings.Contains(problems[0], `service "migrate": Exited (1)`) {
+		t.Fatalf("expected failed one-shot service to be reported, got %#v", problems)
+	}
+}
+
+func TestStackReadinessIgnoresReplacedContainers(t *testing.T) {
+	before := "services:\n  web:\n    image: nginx:1.26\n  db:\n    image: postgres:16\n"
+	after := "services:\n  web:\n    image: nginx:1.27\n  db:\n    image: postgres:16\n"
+	baseline := stackDeployBaseline{
+		compose: before,
+		containers: []stackContainerDetailResponse{
+			{ID: "old-web", Service: "web", State: "running"},
+			{ID: "db1", Service: "db", State: "running"},
+		},
+	}
+
+	stale := staleStackContainers(baseline, after)
+	if _, ok := stale["old-web"]; !ok || len(stale) != 1 {
+		t.Fatalf("expected only the changed service's container to be stale, got %#v", stale)
+	}
+
+	// Right after the compose update the old web container is still running.
+	problems := stackReadinessProblems(baseline.containers, composeServiceSpecs(after), stale)
+	if len(problems) != 1 || !strings.Contains(problems[0], `service "web": waiting for its container to be recreated`) {
+		t.Fatalf("expected web to wait for its new container, got %#v", problems)
+	}
+
+	problems = stackReadinessProblems([]stackContainerDetailResponse{
+		{ID: "new-web", Service: "web", State: "running"},
+		{ID: "db1", Service: "db", State: "running"},
+	}, composeServiceSpecs(after), stale)
+	if len(problems) != 0 {
+		t.Fatalf("expected stack to be ready once web was recreated, got %#v", problems)
+	}
+}